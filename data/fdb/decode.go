@@ -0,0 +1,38 @@
+package fdb
+
+import (
+	"encoding/json"
+	"strings"
+)
+
+func Decode(d []byte) (Root, error) {
+	var root Root
+	if err := json.Unmarshal(d, &root); err != nil {
+		return Root{}, err
+	}
+
+	newProcesses := map[string]Process{}
+
+	for id, p := range root.Cluster.Processes {
+		newAddress, tls := strings.CutSuffix(p.Address, ":tls")
+		p.Address = newAddress
+		p.TLS = tls
+		newProcesses[id] = p
+	}
+
+	root.Cluster.Processes = newProcesses
+
+	for i, sv := range root.Cluster.Clients.SupportedVersions {
+		for j, c := range sv.ConnectedClients {
+			newAddress, tls := strings.CutSuffix(c.Address, ":tls")
+			root.Cluster.Clients.SupportedVersions[i].ConnectedClients[j].Address = newAddress
+			root.Cluster.Clients.SupportedVersions[i].ConnectedClients[j].TLS = tls
+		}
+	}
+
+	for i, c := range root.Client.Coordinators.Coordinators {
+		root.Client.Coordinators.Coordinators[i].Address, _ = strings.CutSuffix(c.Address, ":tls")
+	}
+
+	return root, nil
+}
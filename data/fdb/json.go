@@ -1,9 +1,24 @@
 package fdb
 
 type Root struct {
+	Client  Client  `json:"client"`
 	Cluster Cluster `json:"cluster"`
 }
 
+type Client struct {
+	Coordinators Coordinators `json:"coordinators"`
+}
+
+type Coordinators struct {
+	Coordinators    []Coordinator `json:"coordinators"`
+	QuorumReachable bool          `json:"quorum_reachable"`
+}
+
+type Coordinator struct {
+	Address   string `json:"address"`
+	Reachable bool   `json:"reachable"`
+}
+
 type Cluster struct {
 	Clients           Clients            `json:"clients"`
 	Processes         map[string]Process `json:"processes"`
@@ -14,6 +29,107 @@ type Cluster struct {
 	RecoveryState     RecoveryState      `json:"recovery_state"`
 	Data              Data               `json:"data"`
 	Layers            Layers             `json:"layers"`
+	Configuration     Configuration      `json:"configuration"`
+	QoS               QoS                `json:"qos"`
+	LatencyProbe      LatencyProbe       `json:"latency_probe"`
+	FaultTolerance    FaultTolerance     `json:"fault_tolerance"`
+	Generation        int                `json:"generation"`
+
+	MaintenanceZone             string  `json:"maintenance_zone"`
+	MaintenanceSecondsRemaining float64 `json:"maintenance_seconds_remaining"`
+}
+
+type LatencyProbe struct {
+	TransactionStartSeconds                  float64 `json:"transaction_start_seconds"`
+	ImmediatePriorityTransactionStartSeconds float64 `json:"immediate_priority_transaction_start_seconds"`
+	BatchPriorityTransactionStartSeconds     float64 `json:"batch_priority_transaction_start_seconds"`
+	ReadSeconds                              float64 `json:"read_seconds"`
+	CommitSeconds                            float64 `json:"commit_seconds"`
+}
+
+type FaultTolerance struct {
+	MaxZoneFailuresWithoutLosingAvailability int `json:"max_zone_failures_without_losing_availability"`
+	MaxZoneFailuresWithoutLosingData         int `json:"max_zone_failures_without_losing_data"`
+}
+
+type QoS struct {
+	PerformanceLimitedBy               PerformanceLimitedBy `json:"performance_limited_by"`
+	BatchPerformanceLimitedBy          PerformanceLimitedBy `json:"batch_performance_limited_by"`
+	TransactionsPerSecondLimit         float64              `json:"transactions_per_second_limit"`
+	ReleasedTransactionsPerSecond      float64              `json:"released_transactions_per_second"`
+	BatchTransactionsPerSecondLimit    float64              `json:"batch_transactions_per_second_limit"`
+	BatchReleasedTransactionsPerSecond float64              `json:"batch_released_transactions_per_second"`
+	WorstQueueBytesStorageServer       float64              `json:"worst_queue_bytes_storage_server"`
+	LimitingQueueBytesStorageServer    float64              `json:"limiting_queue_bytes_storage_server"`
+	WorstQueueBytesLogServer           float64              `json:"worst_queue_bytes_log_server"`
+	WorstDurabilityLagStorageServer    Lag                  `json:"worst_durability_lag_storage_server"`
+	LimitingDurabilityLagStorageServer Lag                  `json:"limiting_durability_lag_storage_server"`
+	WorstDataLagStorageServer          Lag                  `json:"worst_data_lag_storage_server"`
+	LimitingDataLagStorageServer       Lag                  `json:"limiting_data_lag_storage_server"`
+	ThrottledTags                      ThrottledTags        `json:"throttled_tags"`
+}
+
+type PerformanceLimitedBy struct {
+	Name           string `json:"name"`
+	Description    string `json:"description"`
+	ReasonServerId string `json:"reason_server_id"`
+	ReasonId       int    `json:"reason_id"`
+}
+
+type ThrottledTags struct {
+	Auto   AutoThrottledTags   `json:"auto"`
+	Manual ManualThrottledTags `json:"manual"`
+}
+
+type AutoThrottledTags struct {
+	BusyRead        int `json:"busy_read"`
+	BusyWrite       int `json:"busy_write"`
+	Count           int `json:"count"`
+	RecommendedOnly int `json:"recommended_only"`
+}
+
+type ManualThrottledTags struct {
+	Count int `json:"count"`
+}
+
+type Configuration struct {
+	RedundancyMode                 string           `json:"redundancy_mode"`
+	StorageEngine                  string           `json:"storage_engine"`
+	Logs                           int              `json:"logs"`
+	AutoLogs                       int              `json:"auto_logs"`
+	RemoteLogs                     int              `json:"remote_logs"`
+	LogRouters                     int              `json:"log_routers"`
+	CommitProxies                  int              `json:"commit_proxies"`
+	AutoCommitProxies              int              `json:"auto_commit_proxies"`
+	GRVProxies                     int              `json:"grv_proxies"`
+	AutoGRVProxies                 int              `json:"auto_grv_proxies"`
+	Proxies                        int              `json:"proxies"`
+	Resolvers                      int              `json:"resolvers"`
+	AutoResolvers                  int              `json:"auto_resolvers"`
+	UsableRegions                  int              `json:"usable_regions"`
+	CoordinatorsCount              int              `json:"coordinators_count"`
+	Regions                        []Region         `json:"regions"`
+	ExcludedServers                []ExcludedServer `json:"excluded_servers"`
+	PerpetualStorageWiggle         int              `json:"perpetual_storage_wiggle"`
+	PerpetualStorageWiggleLocality string           `json:"perpetual_storage_wiggle_locality"`
+	StorageMigrationType           string           `json:"storage_migration_type"`
+}
+
+type Region struct {
+	Datacenters             []RegionDatacenter `json:"datacenters"`
+	SatelliteRedundancyMode string             `json:"satellite_redundancy_mode"`
+	SatelliteLogs           int                `json:"satellite_logs"`
+}
+
+type RegionDatacenter struct {
+	Id        string `json:"id"`
+	Priority  int    `json:"priority"`
+	Satellite int    `json:"satellite"`
+}
+
+type ExcludedServer struct {
+	Address  string `json:"address"`
+	Locality string `json:"locality"`
 }
 
 type Clients struct {
@@ -22,15 +138,19 @@ type Clients struct {
 }
 
 type SupportedVersions struct {
-	Count            int `json:"count"`
-	ClientVersion    string
-	ProtocolVersion  string
-	ConnectedClients []ConnectedClient
+	Count              int               `json:"count"`
+	ClientVersion      string            `json:"client_version"`
+	ProtocolVersion    string            `json:"protocol_version"`
+	SourceVersion      string            `json:"source_version"`
+	ConnectedClients   []ConnectedClient `json:"connected_clients"`
+	MaxProtocolCount   int               `json:"max_protocol_count"`
+	MaxProtocolClients []ConnectedClient `json:"max_protocol_clients"`
 }
 
 type ConnectedClient struct {
-	Address string `json:"address"`
-	TLS     bool   `json:"-"`
+	Address  string `json:"address"`
+	LogGroup string `json:"log_group"`
+	TLS      bool   `json:"-"`
 }
 
 type DatabaseLockState struct {
@@ -125,10 +245,14 @@ type MovingData struct {
 }
 
 type RecoveryState struct {
-	Name        string `json:"name"`
-	Description string `json:"description"`
+	Name                      string  `json:"name"`
+	Description               string  `json:"description"`
+	ActiveGenerations         int     `json:"active_generations"`
+	SecondsSinceLastRecovered float64 `json:"seconds_since_last_recovered"`
 }
 
+const RecoveryStateFullyRecovered = "fully_recovered"
+
 type Workload struct {
 	Transactions Transactions `json:"transactions"`
 	Operations   Operations   `json:"operations"`
@@ -179,20 +303,38 @@ type Message struct {
 const (
 	LocalityDataHall   = "data_hall"
 	LocalityDataCenter = "dcid"
+	LocalityZoneID     = "zoneid"
 	LocalityMachineID  = "machineid"
 	LocalityProcessID  = "processid"
 )
 
+// Roles lists the roles a process can report in status json.
+var Roles = []string{
+	"backup", "blob_manager", "blob_migrator", "blob_worker", "cluster_controller", "commit_proxy", "consistency_scan",
+	"coordinator", "data_distributor", "encrypt_key_proxy", "grv_proxy", "log", "master", "proxy", "ratekeeper",
+	"resolver", "router", "storage", "storage_cache",
+}
+
+// Classes lists the process classes a process can be configured with.
+var Classes = []string{
+	"backup", "blob_manager", "blob_migrator", "blob_worker", "cluster_controller", "commit_proxy", "consistency_scan",
+	"coordinator", "data_distributor", "encrypt_key_proxy", "fast_restore", "grv_proxy", "log", "master", "proxy",
+	"ratekeeper", "resolution", "router", "stateless", "storage", "storage_cache", "test", "transaction", "unset",
+}
+
 type Locality map[string]string
 
 type Role struct {
 	Role string `json:"role"`
+	Id   string `json:"id"`
 
 	// Storage Only
-	KVUsedBytes   float64 `json:"kvstore_used_bytes"`
-	TotalQueries  Stats   `json:"total_queries"`
-	DataLag       Lag     `json:"data_lag"`
-	DurabilityLag Lag     `json:"durability_lag"`
+	KVUsedBytes           float64            `json:"kvstore_used_bytes"`
+	TotalQueries          Stats              `json:"total_queries"`
+	DataLag               Lag                `json:"data_lag"`
+	DurabilityLag         Lag                `json:"durability_lag"`
+	ReadLatencyStatistics LatencyStatistics  `json:"read_latency_statistics"`
+	ReadLatencyBands      map[string]float64 `json:"read_latency_bands"`
 
 	// Log Only
 	QueueUsedBytes float64 `json:"queue_disk_used_bytes"`
@@ -200,6 +342,46 @@ type Role struct {
 	// Both
 	InputBytes   Stats `json:"input_bytes"`
 	DurableBytes Stats `json:"durable_bytes"`
+
+	// Commit Proxy Only
+	CommitLatencyStatistics  LatencyStatistics  `json:"commit_latency_statistics"`
+	CommitLatencyBands       map[string]float64 `json:"commit_latency_bands"`
+	CommitBatchingWindowSize LatencyStatistics  `json:"commit_batching_window_size"`
+
+	// GRV Proxy Only
+	GRVLatencyStatistics GRVLatencyStatistics `json:"grv_latency_statistics"`
+	GRVLatencyBands      map[string]float64   `json:"grv_latency_bands"`
+}
+
+func (r Role) LatencyStatistics() (string, LatencyStatistics, bool) {
+	switch r.Role {
+	case "storage":
+		return "Read", r.ReadLatencyStatistics, true
+	case "commit_proxy", "proxy":
+		return "Commit", r.CommitLatencyStatistics, true
+	case "grv_proxy":
+		return "GRV", r.GRVLatencyStatistics.Default, true
+	default:
+		return "", LatencyStatistics{}, false
+	}
+}
+
+type LatencyStatistics struct {
+	Count  float64 `json:"count"`
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Median float64 `json:"median"`
+	Mean   float64 `json:"mean"`
+	P25    float64 `json:"p25"`
+	P90    float64 `json:"p90"`
+	P95    float64 `json:"p95"`
+	P99    float64 `json:"p99"`
+	P999   float64 `json:"p99.9"`
+}
+
+type GRVLatencyStatistics struct {
+	Default LatencyStatistics `json:"default"`
+	Batch   LatencyStatistics `json:"batch"`
 }
 
 type Lag struct {
@@ -0,0 +1,178 @@
+package safety
+
+import (
+	"fmt"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+var redundancyReplicas = map[string]int{
+	"single":           1,
+	"double":           2,
+	"triple":           3,
+	"three_data_hall":  3,
+	"three_datacenter": 3,
+}
+
+func matches(p fdb.Process, target string) bool {
+	if p.Address == target {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(p.Address)
+	return err == nil && host == target
+}
+
+func roles(p fdb.Process) []string {
+	seen := make(map[string]bool)
+	var names []string
+
+	for _, r := range p.Roles {
+		if !seen[r.Role] {
+			seen[r.Role] = true
+			names = append(names, r.Role)
+		}
+	}
+
+	return names
+}
+
+func CheckExclude(root fdb.Root, targets []string) []string {
+	var reasons []string
+	c := root.Cluster
+
+	if !c.DatabaseAvailable {
+		reasons = append(reasons, "database is unavailable")
+	}
+
+	if !c.Data.State.Health {
+		reasons = append(reasons, fmt.Sprintf("data state is '%s', wait until it is healthy", c.Data.State.Name))
+	}
+
+	excluding := make(map[string]bool)
+
+	for _, target := range targets {
+		found := false
+
+		for id, p := range c.Processes {
+			if matches(p, target) {
+				excluding[id] = true
+				found = true
+			}
+		}
+
+		if !found {
+			reasons = append(reasons, fmt.Sprintf("no process matches '%s'", target))
+		}
+	}
+
+	coordinators := make(map[string]bool)
+
+	for _, coordinator := range root.Client.Coordinators.Coordinators {
+		if coordinators[coordinator.Address] {
+			continue
+		}
+
+		for id := range excluding {
+			if c.Processes[id].Address == coordinator.Address {
+				coordinators[coordinator.Address] = true
+				reasons = append(reasons, fmt.Sprintf("%s is a coordinator", coordinator.Address))
+				break
+			}
+		}
+	}
+
+	required := map[string]int{"storage": 1, "log": 1}
+	if replicas, ok := redundancyReplicas[c.Configuration.RedundancyMode]; ok {
+		required["storage"] = replicas
+	}
+	if c.Configuration.Logs > 0 {
+		required["log"] = c.Configuration.Logs
+	}
+
+	affected := make(map[string]bool)
+	remaining := make(map[string]int)
+
+	for id, p := range c.Processes {
+		for _, role := range roles(p) {
+			if excluding[id] {
+				affected[role] = true
+			} else if !p.Excluded {
+				remaining[role]++
+			}
+		}
+	}
+
+	names := make([]string, 0, len(required))
+	for role := range required {
+		names = append(names, role)
+	}
+	sort.Strings(names)
+
+	for _, role := range names {
+		if affected[role] && remaining[role] < required[role] {
+			reasons = append(reasons, fmt.Sprintf("%d %s processes would remain, %d are required", remaining[role], role, required[role]))
+		}
+	}
+
+	return reasons
+}
+
+func CheckInclude(excluded []string, targets []string) []string {
+	var reasons []string
+
+	for _, target := range targets {
+		found := false
+
+		for _, e := range excluded {
+			if e == target {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			reasons = append(reasons, fmt.Sprintf("'%s' is not excluded", target))
+		}
+	}
+
+	return reasons
+}
+
+func CheckMaintenance(root fdb.Root, zone string, duration time.Duration) []string {
+	var reasons []string
+	c := root.Cluster
+
+	if duration <= 0 {
+		reasons = append(reasons, "duration must be positive")
+	}
+
+	found := false
+	for _, p := range c.Processes {
+		if p.Locality[fdb.LocalityZoneID] == zone {
+			found = true
+			break
+		}
+	}
+
+	if !found {
+		reasons = append(reasons, fmt.Sprintf("no process is in zone '%s'", zone))
+	}
+
+	if c.MaintenanceZone != "" && c.MaintenanceZone != zone {
+		reasons = append(reasons, fmt.Sprintf("zone '%s' is already under maintenance", c.MaintenanceZone))
+	}
+
+	if !c.Data.State.Health {
+		reasons = append(reasons, fmt.Sprintf("data state is '%s', wait until it is healthy", c.Data.State.Name))
+	}
+
+	if c.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability < 1 {
+		reasons = append(reasons, "cluster cannot lose a zone without losing availability")
+	}
+
+	return reasons
+}
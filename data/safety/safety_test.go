@@ -0,0 +1,220 @@
+package safety
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+func process(address string, class string, zone string, roles ...string) fdb.Process {
+	p := fdb.Process{
+		Address:  address,
+		Class:    class,
+		Locality: fdb.Locality{fdb.LocalityZoneID: zone},
+	}
+
+	for _, role := range roles {
+		p.Roles = append(p.Roles, fdb.Role{Role: role})
+	}
+
+	return p
+}
+
+func healthyRoot() fdb.Root {
+	root := fdb.Root{}
+	root.Client.Coordinators.Coordinators = []fdb.Coordinator{{Address: "10.0.0.1:4500", Reachable: true}}
+
+	c := &root.Cluster
+	c.DatabaseAvailable = true
+	c.Data.State = fdb.State{Health: true, Name: "healthy"}
+	c.Configuration.RedundancyMode = "double"
+	c.Configuration.Logs = 2
+	c.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability = 1
+	c.Processes = map[string]fdb.Process{
+		"a": process("10.0.0.1:4500", "storage", "z1", "storage", "coordinator"),
+		"b": process("10.0.0.2:4500", "storage", "z2", "storage"),
+		"c": process("10.0.0.3:4500", "unset", "z3", "storage", "log"),
+		"d": process("10.0.0.4:4500", "log", "z4", "log"),
+		"e": process("10.0.0.5:4500", "unset", "z5", "log"),
+		"f": process("10.0.0.6:4500", "stateless", "z6", "commit_proxy"),
+	}
+
+	return root
+}
+
+func TestCheckExclude(t *testing.T) {
+	tests := []struct {
+		name    string
+		modify  func(*fdb.Root)
+		targets []string
+		reasons []string
+	}{
+		{
+			name:    "stateless process",
+			targets: []string{"10.0.0.6:4500"},
+		},
+		{
+			name:    "storage process with enough remaining",
+			targets: []string{"10.0.0.2:4500"},
+		},
+		{
+			name:    "unset class running storage and log roles",
+			targets: []string{"10.0.0.3:4500"},
+		},
+		{
+			name:    "too few storage roles remain",
+			targets: []string{"10.0.0.2:4500", "10.0.0.3:4500"},
+			reasons: []string{"1 storage processes would remain, 2 are required"},
+		},
+		{
+			name:    "too few log roles remain on unset class",
+			targets: []string{"10.0.0.4:4500", "10.0.0.5"},
+			reasons: []string{"1 log processes would remain, 2 are required"},
+		},
+		{
+			name: "already excluded processes are not counted",
+			modify: func(root *fdb.Root) {
+				p := root.Cluster.Processes["d"]
+				p.Excluded = true
+				root.Cluster.Processes["d"] = p
+			},
+			targets: []string{"10.0.0.5:4500"},
+			reasons: []string{"1 log processes would remain, 2 are required"},
+		},
+		{
+			name:    "coordinator",
+			targets: []string{"10.0.0.1:4500"},
+			reasons: []string{"10.0.0.1:4500 is a coordinator"},
+		},
+		{
+			name: "coordinator matched by host and address",
+			modify: func(root *fdb.Root) {
+				root.Cluster.Processes["g"] = process("10.0.0.1:4501", "stateless", "z1", "commit_proxy")
+				root.Client.Coordinators.Coordinators = append(root.Client.Coordinators.Coordinators, root.Client.Coordinators.Coordinators[0])
+			},
+			targets: []string{"10.0.0.1", "10.0.0.1:4500"},
+			reasons: []string{"10.0.0.1:4500 is a coordinator"},
+		},
+		{
+			name:    "unknown address",
+			targets: []string{"10.0.0.9:4500"},
+			reasons: []string{"no process matches '10.0.0.9:4500'"},
+		},
+		{
+			name: "unavailable and unhealthy",
+			modify: func(root *fdb.Root) {
+				root.Cluster.DatabaseAvailable = false
+				root.Cluster.Data.State = fdb.State{Name: "healing"}
+			},
+			targets: []string{"10.0.0.6:4500"},
+			reasons: []string{"database is unavailable", "data state is 'healing', wait until it is healthy"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := healthyRoot()
+			if test.modify != nil {
+				test.modify(&root)
+			}
+
+			if reasons := CheckExclude(root, test.targets); !reflect.DeepEqual(reasons, test.reasons) {
+				t.Errorf("CheckExclude() = %q, want %q", reasons, test.reasons)
+			}
+		})
+	}
+}
+
+func TestCheckInclude(t *testing.T) {
+	excluded := []string{"10.0.0.2:4500"}
+
+	tests := []struct {
+		name    string
+		targets []string
+		reasons []string
+	}{
+		{name: "excluded", targets: []string{"10.0.0.2:4500"}},
+		{name: "not excluded", targets: []string{"10.0.0.3:4500"}, reasons: []string{"'10.0.0.3:4500' is not excluded"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if reasons := CheckInclude(excluded, test.targets); !reflect.DeepEqual(reasons, test.reasons) {
+				t.Errorf("CheckInclude() = %q, want %q", reasons, test.reasons)
+			}
+		})
+	}
+}
+
+func TestCheckMaintenance(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*fdb.Root)
+		zone     string
+		duration time.Duration
+		reasons  []string
+	}{
+		{
+			name:     "healthy",
+			zone:     "z2",
+			duration: time.Hour,
+		},
+		{
+			name: "extending the current zone",
+			modify: func(root *fdb.Root) {
+				root.Cluster.MaintenanceZone = "z2"
+			},
+			zone:     "z2",
+			duration: time.Hour,
+		},
+		{
+			name:     "non positive duration",
+			zone:     "z2",
+			duration: 0,
+			reasons:  []string{"duration must be positive"},
+		},
+		{
+			name:     "unknown zone",
+			zone:     "z9",
+			duration: time.Hour,
+			reasons:  []string{"no process is in zone 'z9'"},
+		},
+		{
+			name: "another zone under maintenance",
+			modify: func(root *fdb.Root) {
+				root.Cluster.MaintenanceZone = "z1"
+			},
+			zone:     "z2",
+			duration: time.Hour,
+			reasons:  []string{"zone 'z1' is already under maintenance"},
+		},
+		{
+			name: "unhealthy without fault tolerance",
+			modify: func(root *fdb.Root) {
+				root.Cluster.Data.State = fdb.State{Name: "healing"}
+				root.Cluster.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability = 0
+			},
+			zone:     "z2",
+			duration: time.Hour,
+			reasons: []string{
+				"data state is 'healing', wait until it is healthy",
+				"cluster cannot lose a zone without losing availability",
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			root := healthyRoot()
+			if test.modify != nil {
+				test.modify(&root)
+			}
+
+			if reasons := CheckMaintenance(root, test.zone, test.duration); !reflect.DeepEqual(reasons, test.reasons) {
+				t.Errorf("CheckMaintenance() = %q, want %q", reasons, test.reasons)
+			}
+		})
+	}
+}
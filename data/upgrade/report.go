@@ -0,0 +1,167 @@
+package upgrade
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+type ServerVersion struct {
+	Version   string
+	OnTarget  bool
+	Addresses []string
+}
+
+type Client struct {
+	Address        string
+	LogGroup       string
+	TLS            bool
+	ClientVersions []string
+	SupportsTarget bool
+}
+
+func (c Client) MultiVersion() bool {
+	return len(c.ClientVersions) > 1
+}
+
+type Report struct {
+	TargetVersion  string
+	ServerVersions []ServerVersion
+	Clients        []Client
+}
+
+func NewReport(root fdb.Root, target string) Report {
+	report := Report{TargetVersion: target}
+
+	servers := make(map[string][]string)
+	for _, p := range root.Cluster.Processes {
+		servers[p.Version] = append(servers[p.Version], p.Address)
+	}
+
+	for version, addresses := range servers {
+		sort.Strings(addresses)
+
+		onTarget := true
+		if target != "" {
+			serverMajorMinor, _ := VersionMajorMinor(version)
+			targetMajorMinor, _ := VersionMajorMinor(target)
+			onTarget = serverMajorMinor == targetMajorMinor
+		}
+
+		report.ServerVersions = append(report.ServerVersions, ServerVersion{Version: version, OnTarget: onTarget, Addresses: addresses})
+	}
+
+	sort.Slice(report.ServerVersions, func(i, j int) bool {
+		return report.ServerVersions[i].Version < report.ServerVersions[j].Version
+	})
+
+	clients := make(map[string]*Client)
+
+	for _, sv := range root.Cluster.Clients.SupportedVersions {
+		for _, c := range sv.ConnectedClients {
+			client, ok := clients[c.Address]
+			if !ok {
+				client = &Client{Address: c.Address, LogGroup: c.LogGroup, TLS: c.TLS, SupportsTarget: target == ""}
+				clients[c.Address] = client
+			}
+
+			client.ClientVersions = append(client.ClientVersions, sv.ClientVersion)
+
+			if target != "" && Compatible(sv.ProtocolVersion, target) {
+				client.SupportsTarget = true
+			}
+		}
+	}
+
+	for _, client := range clients {
+		sort.Strings(client.ClientVersions)
+		report.Clients = append(report.Clients, *client)
+	}
+
+	sort.Slice(report.Clients, func(i, j int) bool {
+		return report.Clients[i].Address < report.Clients[j].Address
+	})
+
+	return report
+}
+
+func (r Report) MixedVersions() bool {
+	return len(r.ServerVersions) > 1
+}
+
+func (r Report) UnsupportedClients() []Client {
+	var unsupported []Client
+
+	for _, c := range r.Clients {
+		if !c.SupportsTarget {
+			unsupported = append(unsupported, c)
+		}
+	}
+
+	return unsupported
+}
+
+func (r Report) MultiVersionClients() int {
+	count := 0
+
+	for _, c := range r.Clients {
+		if c.MultiVersion() {
+			count++
+		}
+	}
+
+	return count
+}
+
+func (r Report) Ready() bool {
+	return !r.MixedVersions() && len(r.UnsupportedClients()) == 0
+}
+
+func (r Report) WriteText(w io.Writer) error {
+	var lines []string
+
+	target := r.TargetVersion
+	if target == "" {
+		target = "(none, use -target-version)"
+	}
+
+	lines = append(lines, fmt.Sprintf("Target version: %s", target), "")
+	lines = append(lines, fmt.Sprintf("Server versions (%d):", len(r.ServerVersions)))
+
+	for _, sv := range r.ServerVersions {
+		flag := ""
+		if !sv.OnTarget {
+			flag = " [not on target]"
+		}
+
+		lines = append(lines, fmt.Sprintf("  %s: %d processes%s", sv.Version, len(sv.Addresses), flag))
+	}
+
+	if r.MixedVersions() {
+		lines = append(lines, "  WARNING: server processes are running mixed versions")
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Clients: %d connected, %d multi-version, %d single-version", len(r.Clients), r.MultiVersionClients(), len(r.Clients)-r.MultiVersionClients()))
+
+	unsupported := r.UnsupportedClients()
+	if r.TargetVersion != "" {
+		lines = append(lines, fmt.Sprintf("Clients without support for target protocol (%d):", len(unsupported)))
+
+		for _, c := range unsupported {
+			lines = append(lines, fmt.Sprintf("  %s (log group %s, versions %s)", c.Address, c.LogGroup, strings.Join(c.ClientVersions, ", ")))
+		}
+	}
+
+	status := "READY"
+	if !r.Ready() {
+		status = "NOT READY"
+	}
+
+	lines = append(lines, "", fmt.Sprintf("Upgrade readiness: %s", status), "")
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n"))
+	return err
+}
@@ -0,0 +1,76 @@
+package upgrade
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+func root(serverVersions []string, clients map[string][]string) fdb.Root {
+	r := fdb.Root{}
+	r.Cluster.Processes = make(map[string]fdb.Process)
+
+	for i, version := range serverVersions {
+		address := fmt.Sprintf("10.0.0.%d:4500", i+1)
+		r.Cluster.Processes[address] = fdb.Process{Address: address, Version: version}
+	}
+
+	for protocol, addresses := range clients {
+		sv := fdb.SupportedVersions{ProtocolVersion: protocol}
+		for _, address := range addresses {
+			sv.ConnectedClients = append(sv.ConnectedClients, fdb.ConnectedClient{Address: address})
+		}
+		r.Cluster.Clients.SupportedVersions = append(r.Cluster.Clients.SupportedVersions, sv)
+	}
+
+	return r
+}
+
+func TestReportReady(t *testing.T) {
+	tests := []struct {
+		name     string
+		root     fdb.Root
+		target   string
+		expected bool
+	}{
+		{
+			name:     "single version with supporting clients",
+			root:     root([]string{"7.1.57", "7.1.57"}, map[string][]string{"fdb00b071010000": {"a", "b"}, "fdb00b073000000": {"a", "b"}}),
+			target:   "7.3",
+			expected: true,
+		},
+		{
+			name:     "client without target protocol",
+			root:     root([]string{"7.1.57"}, map[string][]string{"fdb00b071010000": {"a", "b"}, "fdb00b073000000": {"a"}}),
+			target:   "7.3",
+			expected: false,
+		},
+		{
+			name:     "mixed server versions",
+			root:     root([]string{"7.1.57", "7.3.27"}, map[string][]string{"fdb00b071010000": {"a"}, "fdb00b073000000": {"a"}}),
+			target:   "7.3",
+			expected: false,
+		},
+		{
+			name:     "mixed server patch versions",
+			root:     root([]string{"7.1.57", "7.1.59"}, map[string][]string{"fdb00b073000000": {"a"}}),
+			target:   "7.3",
+			expected: false,
+		},
+		{
+			name:     "no clients",
+			root:     root([]string{"7.1.57"}, nil),
+			target:   "7.3",
+			expected: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := NewReport(test.root, test.target).Ready(); actual != test.expected {
+				t.Errorf("Ready() = %t, want %t", actual, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,52 @@
+package upgrade
+
+import (
+	"flag"
+	"strings"
+)
+
+var targetVersion *string
+
+func init() {
+	targetVersion = flag.String("target-version", "", "FoundationDB version (e.g. 7.3) being upgraded to, clients without support for its protocol are flagged.")
+}
+
+func TargetVersion() string {
+	return *targetVersion
+}
+
+func VersionMajorMinor(version string) (string, bool) {
+	parts := strings.Split(version, ".")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", false
+	}
+
+	return parts[0] + "." + parts[1], true
+}
+
+func ProtocolMajorMinor(protocol string) (string, bool) {
+	protocol = strings.TrimPrefix(strings.ToLower(protocol), "0x")
+
+	if len(protocol) < 9 || !strings.HasPrefix(protocol, "fdb00b") {
+		return "", false
+	}
+
+	major := strings.TrimLeft(protocol[6:8], "0")
+	minor := protocol[8:9]
+
+	return major + "." + minor, true
+}
+
+func Compatible(protocol string, version string) bool {
+	protocolMajorMinor, ok := ProtocolMajorMinor(protocol)
+	if !ok {
+		return false
+	}
+
+	versionMajorMinor, ok := VersionMajorMinor(version)
+	if !ok {
+		return false
+	}
+
+	return protocolMajorMinor == versionMajorMinor
+}
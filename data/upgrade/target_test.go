@@ -0,0 +1,74 @@
+package upgrade
+
+import "testing"
+
+func TestVersionMajorMinor(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+		ok       bool
+	}{
+		{version: "7.3.27", expected: "7.3", ok: true},
+		{version: "7.3", expected: "7.3", ok: true},
+		{version: "10.0.1", expected: "10.0", ok: true},
+		{version: "7", ok: false},
+		{version: "7.", ok: false},
+		{version: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.version, func(t *testing.T) {
+			actual, ok := VersionMajorMinor(test.version)
+			if actual != test.expected || ok != test.ok {
+				t.Errorf("VersionMajorMinor(%q) = %q, %t, want %q, %t", test.version, actual, ok, test.expected, test.ok)
+			}
+		})
+	}
+}
+
+func TestProtocolMajorMinor(t *testing.T) {
+	tests := []struct {
+		protocol string
+		expected string
+		ok       bool
+	}{
+		{protocol: "fdb00b071010000", expected: "7.1", ok: true},
+		{protocol: "fdb00b073000000", expected: "7.3", ok: true},
+		{protocol: "fdb00b063010001", expected: "6.3", ok: true},
+		{protocol: "0xFDB00B072000000", expected: "7.2", ok: true},
+		{protocol: "fdb00a071010000", ok: false},
+		{protocol: "fdb00b07", ok: false},
+		{protocol: "", ok: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.protocol, func(t *testing.T) {
+			actual, ok := ProtocolMajorMinor(test.protocol)
+			if actual != test.expected || ok != test.ok {
+				t.Errorf("ProtocolMajorMinor(%q) = %q, %t, want %q, %t", test.protocol, actual, ok, test.expected, test.ok)
+			}
+		})
+	}
+}
+
+func TestCompatible(t *testing.T) {
+	tests := []struct {
+		protocol string
+		version  string
+		expected bool
+	}{
+		{protocol: "fdb00b073000000", version: "7.3", expected: true},
+		{protocol: "fdb00b073000000", version: "7.3.27", expected: true},
+		{protocol: "fdb00b071010000", version: "7.3", expected: false},
+		{protocol: "fdb00b073000000", version: "7", expected: false},
+		{protocol: "invalid", version: "7.3", expected: false},
+	}
+
+	for _, test := range tests {
+		t.Run(test.protocol+"/"+test.version, func(t *testing.T) {
+			if actual := Compatible(test.protocol, test.version); actual != test.expected {
+				t.Errorf("Compatible(%q, %q) = %t, want %t", test.protocol, test.version, actual, test.expected)
+			}
+		})
+	}
+}
@@ -19,7 +19,11 @@ func NewFile() (*File, bool) {
 		return nil, false
 	}
 
-	return &File{fn: *inputFile}, true
+	return Open(*inputFile), true
+}
+
+func Open(fn string) *File {
+	return &File{fn: fn}
 }
 
 type File struct {
@@ -8,7 +8,9 @@ import (
 	"fmt"
 	"github.com/apple/foundationdb/bindings/go/src/fdb"
 	"os"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var clusterFile *string
@@ -31,6 +33,17 @@ func NewFDB() (*FDB, bool) {
 	return f, true
 }
 
+func Open(clusterFile string) (*FDB, error) {
+	fdb.MustAPIVersion(700)
+
+	db, err := fdb.OpenDatabase(clusterFile)
+	if err != nil {
+		return nil, fmt.Errorf("foundationdb err: %w", err)
+	}
+
+	return &FDB{clusterFile: clusterFile, db: db}, nil
+}
+
 type FDB struct {
 	clusterFile string
 	db          fdb.Database
@@ -114,6 +127,102 @@ func (f *FDB) IncludeProcess(includeKey string) error {
 	}
 }
 
+func (f *FDB) StartMaintenance(zone string, duration time.Duration) error {
+	if _, err := f.db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		if err := tr.Options().SetReadLockAware(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetLockAware(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetPrioritySystemImmediate(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetSpecialKeySpaceEnableWrites(); err != nil {
+			return nil, err
+		}
+
+		tr.Set(fdb.Key(fmt.Sprintf("\xff\xff/management/maintenance/%s", zone)), []byte(strconv.Itoa(int(duration.Seconds()))))
+
+		return nil, nil
+	}); err != nil {
+		return fmt.Errorf("foundationdb err: %w", err)
+	} else {
+		return nil
+	}
+}
+
+func (f *FDB) EndMaintenance(zone string) error {
+	if _, err := f.db.Transact(func(tr fdb.Transaction) (interface{}, error) {
+		if err := tr.Options().SetReadLockAware(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetLockAware(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetPrioritySystemImmediate(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetSpecialKeySpaceEnableWrites(); err != nil {
+			return nil, err
+		}
+
+		tr.Clear(fdb.Key(fmt.Sprintf("\xff\xff/management/maintenance/%s", zone)))
+
+		return nil, nil
+	}); err != nil {
+		return fmt.Errorf("foundationdb err: %w", err)
+	} else {
+		return nil
+	}
+}
+
+func (f *FDB) MaintenanceZones() (map[string]time.Duration, error) {
+	if zones, err := f.db.ReadTransact(func(tr fdb.ReadTransaction) (interface{}, error) {
+		if err := tr.Options().SetReadLockAware(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetLockAware(); err != nil {
+			return nil, err
+		}
+
+		if err := tr.Options().SetPrioritySystemImmediate(); err != nil {
+			return nil, err
+		}
+
+		keyPrefix := "\xff\xff/management/maintenance/"
+		result, err := tr.GetRange(fdb.KeyRange{Begin: fdb.Key(keyPrefix), End: fdb.Key(fmt.Sprintf("%s\xff", keyPrefix))}, fdb.RangeOptions{Mode: fdb.StreamingModeWantAll}).GetSliceWithError()
+
+		if err != nil {
+			return nil, err
+		}
+
+		zones := make(map[string]time.Duration)
+
+		for _, v := range result {
+			seconds, err := strconv.ParseFloat(string(v.Value), 64)
+			if err != nil {
+				return nil, fmt.Errorf("maintenance zone %s: %w", v.Key.String(), err)
+			}
+
+			zones[strings.TrimPrefix(string(v.Key), keyPrefix)] = time.Duration(seconds * float64(time.Second))
+		}
+
+		return zones, nil
+	}); err != nil {
+		return nil, fmt.Errorf("foundationdb err: %w", err)
+	} else {
+		return zones.(map[string]time.Duration), nil
+	}
+}
+
 func (f *FDB) ExcludedProcesses() ([]string, error) {
 	return f.getProcesses("\xff\xff/management/excluded/")
 }
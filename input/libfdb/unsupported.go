@@ -4,12 +4,17 @@ package libfdb
 
 import (
 	"encoding/json"
+	"errors"
 )
 
 func NewFDB() (*FDB, bool) {
 	return nil, false
 }
 
+func Open(_ string) (*FDB, error) {
+	return nil, errors.New("this build does not support connecting to FoundationDB")
+}
+
 type FDB struct {
 }
 
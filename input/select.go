@@ -5,12 +5,18 @@ import (
 	"github.com/pwood/fdbexplorer/input/file"
 	"github.com/pwood/fdbexplorer/input/libfdb"
 	"github.com/pwood/fdbexplorer/input/url"
+	"strings"
+	"time"
 )
 
 type StatusProvider interface {
 	Status() (json.RawMessage, error)
 }
 
+type StatusNotifier interface {
+	Updated() <-chan struct{}
+}
+
 type ExclusionManager interface {
 	IncludeProcess(includeKey string) error
 	ExcludeProcess(excludeKey string) error
@@ -18,11 +24,44 @@ type ExclusionManager interface {
 	ExclusionInProgressProcesses() ([]string, error)
 }
 
+type MaintenanceManager interface {
+	StartMaintenance(zone string, duration time.Duration) error
+	EndMaintenance(zone string) error
+	MaintenanceZones() (map[string]time.Duration, error)
+}
+
+type ClusterSwitcher interface {
+	Clusters() ([]string, error)
+	Cluster() string
+	SwitchCluster(name string) error
+}
+
+func Open(source string) (StatusProvider, error) {
+	if strings.HasPrefix(source, "http://") || strings.HasPrefix(source, "https://") {
+		return url.Open(source), nil
+	}
+
+	if fn, ok := strings.CutPrefix(source, "file:"); ok {
+		return file.Open(fn), nil
+	}
+
+	src, err := libfdb.Open(source)
+	if err != nil {
+		return nil, err
+	}
+
+	return src, nil
+}
+
 func Select() StatusProvider {
 	if src, ok := file.NewFile(); ok {
 		return src
 	}
 
+	if src, ok := url.NewIndex(); ok {
+		return src
+	}
+
 	if src, ok := url.NewURL(); ok {
 		return src
 	}
@@ -0,0 +1,123 @@
+package url
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	neturl "net/url"
+	"strings"
+	"sync"
+)
+
+type indexEntry struct {
+	Name      string `json:"name"`
+	StatusURL string `json:"status_url"`
+	StreamURL string `json:"stream_url"`
+}
+
+func NewIndex() (*Index, bool) {
+	if len(*url) == 0 {
+		return nil, false
+	}
+
+	u, err := neturl.Parse(*url)
+	if err != nil || !strings.HasSuffix(strings.TrimSuffix(u.Path, "/"), "/clusters") {
+		return nil, false
+	}
+
+	return &Index{URL: newURL("", *urlStream), index: u, cluster: *urlCluster}, true
+}
+
+type Index struct {
+	*URL
+	index *neturl.URL
+
+	im      sync.RWMutex
+	cluster string
+}
+
+func (i *Index) Status() (json.RawMessage, error) {
+	if i.target() == "" {
+		if err := i.SwitchCluster(i.Cluster()); err != nil {
+			return nil, fmt.Errorf("url index err: %w", err)
+		}
+	}
+
+	return i.URL.Status()
+}
+
+func (i *Index) entries() ([]indexEntry, error) {
+	d, err := get(i.index.String())
+	if err != nil {
+		return nil, err
+	}
+
+	var entries []indexEntry
+	if err := json.Unmarshal(d, &entries); err != nil {
+		return nil, fmt.Errorf("index: %w", err)
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.New("index lists no clusters")
+	}
+
+	return entries, nil
+}
+
+func (i *Index) Clusters() ([]string, error) {
+	entries, err := i.entries()
+	if err != nil {
+		return nil, err
+	}
+
+	names := make([]string, 0, len(entries))
+	for _, e := range entries {
+		names = append(names, e.Name)
+	}
+
+	return names, nil
+}
+
+func (i *Index) Cluster() string {
+	i.im.RLock()
+	defer i.im.RUnlock()
+
+	return i.cluster
+}
+
+func (i *Index) SwitchCluster(name string) error {
+	entries, err := i.entries()
+	if err != nil {
+		return err
+	}
+
+	var entry *indexEntry
+	for n := range entries {
+		if name == "" || entries[n].Name == name {
+			entry = &entries[n]
+			break
+		}
+	}
+
+	if entry == nil {
+		return fmt.Errorf("cluster %q is not in the index", name)
+	}
+
+	ref := entry.StatusURL
+	if i.stream {
+		ref = entry.StreamURL
+	}
+
+	target, err := i.index.Parse(ref)
+	if err != nil {
+		return fmt.Errorf("cluster %q: %w", entry.Name, err)
+	}
+
+	i.im.Lock()
+	i.cluster = entry.Name
+	i.im.Unlock()
+
+	i.retarget(target.String())
+
+	return nil
+}
@@ -1,41 +1,111 @@
 package url
 
 import (
+	"bufio"
+	"bytes"
+	"context"
 	"encoding/json"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
 	"net/http"
+	"strings"
+	"sync"
+	"time"
 )
 
 var url *string
+var urlStream *bool
+var urlCluster *string
 
 func init() {
 	url = flag.String("url", "", "URL to fetch status json from periodically.")
+	urlCluster = flag.String("url-cluster", "", "Name of the cluster to explore first when -url is a fdbexplorer /clusters index, the first listed if empty.")
+	urlStream = flag.Bool("url-stream", false, "Subscribe to -url as a fdbexplorer /status/stream endpoint, rather than polling it.")
 }
 
+const (
+	streamMaxEventBytes = 256 * 1024 * 1024
+	streamMinBackoff    = time.Second
+	streamMaxBackoff    = 30 * time.Second
+)
+
+var errNoSnapshot = errors.New("waiting for first snapshot from stream")
+var errNoTarget = errors.New("waiting for a cluster to be selected")
+
 func NewURL() (*URL, bool) {
 	if len(*url) == 0 {
 		return nil, false
 	}
 
-	return &URL{url: *url}, true
+	return newURL(*url, *urlStream), true
+}
+
+func Open(u string) *URL {
+	return newURL(u, false)
+}
+
+func newURL(u string, stream bool) *URL {
+	f := &URL{url: u, stream: stream}
+
+	if f.stream {
+		f.updated = make(chan struct{}, 1)
+		go f.subscribe()
+	}
+
+	return f
 }
 
 type URL struct {
-	url string
+	url    string
+	stream bool
+
+	m       sync.RWMutex
+	latest  json.RawMessage
+	err     error
+	updated chan struct{}
+	cancel  context.CancelFunc
+}
+
+func (f *URL) target() string {
+	f.m.RLock()
+	defer f.m.RUnlock()
+
+	return f.url
+}
+
+func (f *URL) retarget(u string) {
+	f.m.Lock()
+	defer f.m.Unlock()
+
+	f.url = u
+	f.latest = nil
+	f.err = nil
+
+	if f.cancel != nil {
+		f.cancel()
+	}
 }
 
 func (f *URL) Status() (json.RawMessage, error) {
-	if d, err := f.get(); err != nil {
+	if f.stream {
+		return f.streamed()
+	}
+
+	if d, err := get(f.target()); err != nil {
 		return nil, fmt.Errorf("url fetch err: %w", err)
 	} else {
 		return d, nil
 	}
 }
 
-func (f *URL) get() ([]byte, error) {
-	req, err := http.NewRequest(http.MethodGet, f.url, nil)
+func (f *URL) Updated() <-chan struct{} {
+	return f.updated
+}
+
+func get(u string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, u, nil)
 	if err != nil {
 		return nil, fmt.Errorf("request: %w", err)
 	}
@@ -45,6 +115,10 @@ func (f *URL) get() ([]byte, error) {
 		return nil, fmt.Errorf("http do: %w", err)
 	}
 
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
 	if res.StatusCode != http.StatusOK {
 		return nil, fmt.Errorf("http response: not 200, was %d", res.StatusCode)
 	}
@@ -56,3 +130,119 @@ func (f *URL) get() ([]byte, error) {
 
 	return resBody, nil
 }
+
+func (f *URL) streamed() (json.RawMessage, error) {
+	f.m.RLock()
+	defer f.m.RUnlock()
+
+	if f.latest == nil {
+		if f.err != nil && !errors.Is(f.err, context.Canceled) {
+			return nil, fmt.Errorf("url stream err: %w", f.err)
+		}
+		return nil, errNoSnapshot
+	}
+
+	return f.latest, nil
+}
+
+func (f *URL) subscribe() {
+	backoff := streamMinBackoff
+
+	for {
+		err := f.consume(func() { backoff = streamMinBackoff })
+
+		f.m.Lock()
+		f.err = err
+		f.m.Unlock()
+
+		time.Sleep(backoff)
+
+		if backoff *= 2; backoff > streamMaxBackoff {
+			backoff = streamMaxBackoff
+		}
+	}
+}
+
+func (f *URL) consume(connected func()) error {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	f.m.Lock()
+	u := f.url
+	f.cancel = cancel
+	f.m.Unlock()
+
+	if u == "" {
+		return errNoTarget
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return fmt.Errorf("request: %w", err)
+	}
+
+	req.Header.Set("Accept", "text/event-stream")
+
+	res, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("http do: %w", err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("http response: not 200, was %d", res.StatusCode)
+	}
+
+	connected()
+
+	scanner := bufio.NewScanner(res.Body)
+	scanner.Buffer(make([]byte, 64*1024), streamMaxEventBytes)
+
+	event := ""
+	var data bytes.Buffer
+
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		switch {
+		case line == "":
+			if event == "snapshot" && data.Len() > 0 {
+				f.receive(ctx, append(json.RawMessage(nil), data.Bytes()...))
+			}
+			event = ""
+			data.Reset()
+		case strings.HasPrefix(line, ":"):
+		case strings.HasPrefix(line, "event:"):
+			event = strings.TrimSpace(strings.TrimPrefix(line, "event:"))
+		case strings.HasPrefix(line, "data:"):
+			if data.Len() > 0 {
+				data.WriteByte('\n')
+			}
+			data.WriteString(strings.TrimPrefix(strings.TrimPrefix(line, "data:"), " "))
+		}
+	}
+
+	if err := scanner.Err(); err != nil {
+		return fmt.Errorf("stream read: %w", err)
+	}
+
+	return io.ErrUnexpectedEOF
+}
+
+func (f *URL) receive(ctx context.Context, d json.RawMessage) {
+	f.m.Lock()
+	if ctx.Err() != nil {
+		f.m.Unlock()
+		return
+	}
+	f.latest = d
+	f.err = nil
+	f.m.Unlock()
+
+	select {
+	case f.updated <- struct{}{}:
+	default:
+	}
+}
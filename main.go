@@ -6,6 +6,7 @@ import (
 	"github.com/carlmjohnson/versioninfo"
 	"github.com/pwood/fdbexplorer/input"
 	"github.com/pwood/fdbexplorer/output"
+	"github.com/pwood/fdbexplorer/output/http"
 	"os"
 )
 
@@ -14,9 +15,12 @@ func main() {
 
 	flag.Parse()
 
-	in := input.Select()
-	if in == nil {
-		usage()
+	var in input.StatusProvider
+
+	if !http.Proxying() {
+		if in = input.Select(); in == nil {
+			usage()
+		}
 	}
 
 	if out := output.Select(in); out == nil {
@@ -0,0 +1,28 @@
+package output
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pwood/fdbexplorer/output/http"
+	"github.com/pwood/fdbexplorer/output/ui"
+)
+
+type Combined struct {
+	http *http.HTTP
+	ui   *ui.Main
+}
+
+func (c *Combined) Run() {
+	ln, err := c.http.Listen()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to start http output: %s\n", err.Error())
+		os.Exit(1)
+	}
+
+	go func() {
+		_ = c.http.Serve(ln)
+	}()
+
+	c.ui.Run()
+}
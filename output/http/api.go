@@ -0,0 +1,413 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"slices"
+	"sort"
+	"strings"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+)
+
+type apiStorage struct {
+	DataLagSeconds       float64 `json:"data_lag_seconds"`
+	DurabilityLagSeconds float64 `json:"durability_lag_seconds"`
+	KVUsedBytes          float64 `json:"kv_used_bytes"`
+	QueueBytes           float64 `json:"queue_bytes"`
+}
+
+type apiLog struct {
+	QueueBytes         float64 `json:"queue_bytes"`
+	QueueDiskUsedBytes float64 `json:"queue_disk_used_bytes"`
+}
+
+type apiProcess struct {
+	Address              string        `json:"address"`
+	TLS                  bool          `json:"tls"`
+	Class                string        `json:"class"`
+	Roles                []string      `json:"roles"`
+	Locality             fdb.Locality  `json:"locality"`
+	Version              string        `json:"version"`
+	Health               string        `json:"health"`
+	Status               string        `json:"status"`
+	Excluded             bool          `json:"excluded"`
+	Degraded             bool          `json:"degraded"`
+	UnderMaintenance     bool          `json:"under_maintenance"`
+	UptimeSeconds        float64       `json:"uptime_seconds"`
+	CPUCores             float64       `json:"cpu_cores"`
+	MemoryRSSBytes       int           `json:"memory_rss_bytes"`
+	MemoryAvailableBytes int           `json:"memory_available_bytes"`
+	DiskBusy             float64       `json:"disk_busy"`
+	DiskFreeBytes        int           `json:"disk_free_bytes"`
+	DiskTotalBytes       int           `json:"disk_total_bytes"`
+	NetworkSentMbps      float64       `json:"network_sent_mbps"`
+	NetworkReceivedMbps  float64       `json:"network_received_mbps"`
+	LatencyOutliers      []string      `json:"latency_outliers,omitempty"`
+	Messages             []fdb.Message `json:"messages,omitempty"`
+	Storage              *apiStorage   `json:"storage,omitempty"`
+	Log                  *apiLog       `json:"log,omitempty"`
+}
+
+type apiHealth struct {
+	Healthy              bool               `json:"healthy"`
+	Health               string             `json:"health"`
+	MinReplicasRemaining int                `json:"min_replicas_remaining"`
+	RebalanceQueuedBytes int                `json:"rebalance_queued_bytes"`
+	RebalanceInFlight    int                `json:"rebalance_in_flight_bytes"`
+	RecoveryState        string             `json:"recovery_state"`
+	RecoveryDescription  string             `json:"recovery_description"`
+	DatabaseLocked       bool               `json:"database_locked"`
+	RedundancyMode       string             `json:"redundancy_mode"`
+	LatencyProbe         fdb.LatencyProbe   `json:"latency_probe"`
+	FaultTolerance       fdb.FaultTolerance `json:"fault_tolerance"`
+}
+
+type apiWorkload struct {
+	TxStarted      float64 `json:"transactions_started_per_second"`
+	TxCommitted    float64 `json:"transactions_committed_per_second"`
+	TxConflicted   float64 `json:"transactions_conflicted_per_second"`
+	TxRejected     float64 `json:"transactions_rejected_per_second"`
+	Reads          float64 `json:"reads_per_second"`
+	Writes         float64 `json:"writes_per_second"`
+	BytesRead      float64 `json:"bytes_read_per_second"`
+	BytesWritten   float64 `json:"bytes_written_per_second"`
+	MovingInFlight int     `json:"moving_data_in_flight_bytes"`
+	MovingInQueue  int     `json:"moving_data_in_queue_bytes"`
+}
+
+type apiBackupTag struct {
+	Id string `json:"id"`
+	fdb.BackupTag
+}
+
+type apiBackups struct {
+	Instances []fdb.BackupInstance `json:"instances"`
+	Tags      []apiBackupTag       `json:"tags"`
+}
+
+type apiDRBackupTag struct {
+	Id string `json:"id"`
+	fdb.DRBackupTag
+}
+
+type apiDRBackup struct {
+	Instances []fdb.DRBackupInstance `json:"instances"`
+	Tags      []apiDRBackupTag       `json:"tags"`
+}
+
+type apiDR struct {
+	Source      apiDRBackup `json:"source"`
+	Destination apiDRBackup `json:"destination"`
+}
+
+var processSorts = map[string]func(apiProcess, apiProcess) int{
+	"address": compareAddress,
+	"class":   func(i, j apiProcess) int { return strings.Compare(i.Class, j.Class) },
+	"role": func(i, j apiProcess) int {
+		return strings.Compare(strings.Join(i.Roles, ","), strings.Join(j.Roles, ","))
+	},
+	"health":    func(i, j apiProcess) int { return strings.Compare(i.Health, j.Health) },
+	"uptime":    func(i, j apiProcess) int { return compareFloat(i.UptimeSeconds, j.UptimeSeconds) },
+	"cpu":       func(i, j apiProcess) int { return compareFloat(i.CPUCores, j.CPUCores) },
+	"memory":    func(i, j apiProcess) int { return i.MemoryRSSBytes - j.MemoryRSSBytes },
+	"disk_busy": func(i, j apiProcess) int { return compareFloat(i.DiskBusy, j.DiskBusy) },
+	"disk_free": func(i, j apiProcess) int { return i.DiskFreeBytes - j.DiskFreeBytes },
+}
+
+var localityParams = map[string]string{
+	"dc":        fdb.LocalityDataCenter,
+	"zone":      fdb.LocalityZoneID,
+	"machine":   fdb.LocalityMachineID,
+	"data_hall": fdb.LocalityDataHall,
+}
+
+func compareAddress(i, j apiProcess) int {
+	iAddrPort, _ := netip.ParseAddrPort(i.Address)
+	jAddrPort, _ := netip.ParseAddrPort(j.Address)
+
+	return iAddrPort.Compare(jAddrPort)
+}
+
+func compareFloat(i, j float64) int {
+	switch {
+	case i < j:
+		return -1
+	case i > j:
+		return 1
+	default:
+		return 0
+	}
+}
+
+func (h *HTTP) root() (fdb.Root, error) {
+	e, _, err := h.cache.get()
+	if err != nil {
+		return fdb.Root{}, err
+	}
+
+	return fdb.Decode(e.data)
+}
+
+func (h *HTTP) serveAPI(w http.ResponseWriter, r *http.Request) {
+	root, err := h.root()
+	if err != nil {
+		statusError(w, err)
+		return
+	}
+
+	var body any
+
+	switch strings.TrimPrefix(r.URL.Path, "/api/v1/") {
+	case "processes":
+		if body, err = apiProcesses(root, r.URL.Query()); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	case "health":
+		body = apiHealthOf(root)
+	case "workload":
+		body = apiWorkloadOf(root)
+	case "backups":
+		body = apiBackupsOf(root)
+	case "dr":
+		body = apiDROf(root)
+	default:
+		http.NotFound(w, r)
+		return
+	}
+
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func processFilter(q url.Values) (func(process.Process) bool, error) {
+	var filters []func(process.Process) bool
+
+	for _, role := range q["role"] {
+		if !slices.Contains(fdb.Roles, role) {
+			return nil, fmt.Errorf("unknown role %q", role)
+		}
+	}
+
+	for _, class := range q["class"] {
+		if !slices.Contains(fdb.Classes, class) {
+			return nil, fmt.Errorf("unknown class %q", class)
+		}
+	}
+
+	if roles := q["role"]; len(roles) > 0 {
+		filters = append(filters, func(p process.Process) bool {
+			for _, role := range roles {
+				if views.RoleMatch(role)(p) {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	if classes := q["class"]; len(classes) > 0 {
+		filters = append(filters, func(p process.Process) bool {
+			for _, class := range classes {
+				if p.FDBData.Class == class {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	for param, values := range q {
+		key, ok := localityParams[param]
+		if !ok {
+			if key, ok = strings.CutPrefix(param, "locality."); !ok {
+				continue
+			}
+		}
+
+		filters = append(filters, func(p process.Process) bool {
+			for _, v := range values {
+				if p.FDBData.Locality[key] == v {
+					return true
+				}
+			}
+			return false
+		})
+	}
+
+	return func(p process.Process) bool {
+		for _, fn := range filters {
+			if !fn(p) {
+				return false
+			}
+		}
+		return true
+	}, nil
+}
+
+func apiProcesses(root fdb.Root, q url.Values) ([]apiProcess, error) {
+	sortKey := q.Get("sort")
+	if sortKey == "" {
+		sortKey = "address"
+	}
+
+	descending := strings.HasPrefix(sortKey, "-")
+	sortFn, ok := processSorts[strings.TrimPrefix(sortKey, "-")]
+	if !ok {
+		return nil, fmt.Errorf("unknown sort %q", sortKey)
+	}
+
+	filter, err := processFilter(q)
+	if err != nil {
+		return nil, err
+	}
+
+	processes := []apiProcess{}
+	for _, p := range process.Processes(root) {
+		if filter(p) {
+			processes = append(processes, apiProcessOf(p))
+		}
+	}
+
+	sort.SliceStable(processes, func(i, j int) bool {
+		c := sortFn(processes[i], processes[j])
+		if descending {
+			c = -c
+		}
+
+		if c != 0 {
+			return c < 0
+		}
+
+		return compareAddress(processes[i], processes[j]) < 0
+	})
+
+	return processes, nil
+}
+
+func apiProcessOf(p process.Process) apiProcess {
+	fp := p.FDBData
+
+	ap := apiProcess{
+		Address:              fp.Address,
+		TLS:                  fp.TLS,
+		Class:                fp.Class,
+		Roles:                []string{},
+		Locality:             fp.Locality,
+		Version:              fp.Version,
+		Health:               p.Metadata.Health.String(),
+		Status:               views.ColumnStatus.DataFn(p),
+		Excluded:             fp.Excluded,
+		Degraded:             fp.Degraded,
+		UnderMaintenance:     fp.UnderMaintenance,
+		UptimeSeconds:        fp.Uptime,
+		CPUCores:             fp.CPU.UsageCores,
+		MemoryRSSBytes:       fp.Memory.RSSBytes,
+		MemoryAvailableBytes: fp.Memory.AvailableBytes,
+		DiskBusy:             fp.Disk.Busy,
+		DiskFreeBytes:        fp.Disk.FreeBytes,
+		DiskTotalBytes:       fp.Disk.TotalBytes,
+		NetworkSentMbps:      fp.Network.MegabitsSent.Hz,
+		NetworkReceivedMbps:  fp.Network.MegabitsReceived.Hz,
+		Messages:             fp.Messages,
+	}
+
+	for role, outlier := range p.Metadata.LatencyOutliers {
+		if outlier {
+			ap.LatencyOutliers = append(ap.LatencyOutliers, role)
+		}
+	}
+	sort.Strings(ap.LatencyOutliers)
+
+	for _, role := range fp.Roles {
+		ap.Roles = append(ap.Roles, role.Role)
+
+		switch role.Role {
+		case "storage":
+			ap.Storage = &apiStorage{
+				DataLagSeconds:       role.DataLag.Seconds,
+				DurabilityLagSeconds: role.DurabilityLag.Seconds,
+				KVUsedBytes:          role.KVUsedBytes,
+				QueueBytes:           role.InputBytes.Counter - role.DurableBytes.Counter,
+			}
+		case "log":
+			ap.Log = &apiLog{
+				QueueBytes:         role.InputBytes.Counter - role.DurableBytes.Counter,
+				QueueDiskUsedBytes: role.QueueUsedBytes,
+			}
+		}
+	}
+
+	return ap
+}
+
+func apiHealthOf(root fdb.Root) apiHealth {
+	ch := views.ClusterHealthOf(root)
+
+	return apiHealth{
+		Healthy:              ch.Healthy,
+		Health:               root.Cluster.Data.State.Name,
+		MinReplicasRemaining: ch.MinReplicas,
+		RebalanceQueuedBytes: ch.RebalanceQueued,
+		RebalanceInFlight:    ch.RebalanceInFlight,
+		RecoveryState:        root.Cluster.RecoveryState.Name,
+		RecoveryDescription:  ch.RecoveryDescription,
+		DatabaseLocked:       ch.DatabaseLocked,
+		RedundancyMode:       ch.RedundancyMode,
+		LatencyProbe:         ch.LatencyProbe,
+		FaultTolerance:       ch.FaultTolerance,
+	}
+}
+
+func apiWorkloadOf(root fdb.Root) apiWorkload {
+	cs := views.ClusterStatsOf(root)
+
+	return apiWorkload{
+		TxStarted:      cs.TxStarted,
+		TxCommitted:    cs.TxCommitted,
+		TxConflicted:   cs.TxConflicted,
+		TxRejected:     cs.TxRejected,
+		Reads:          cs.Reads,
+		Writes:         cs.Writes,
+		BytesRead:      cs.BytesRead,
+		BytesWritten:   cs.BytesWritten,
+		MovingInFlight: root.Cluster.Data.MovingData.InFlightBytes,
+		MovingInQueue:  root.Cluster.Data.MovingData.InQueueBytes,
+	}
+}
+
+func apiBackupsOf(root fdb.Root) apiBackups {
+	b := apiBackups{Instances: []fdb.BackupInstance{}, Tags: []apiBackupTag{}}
+
+	b.Instances = append(b.Instances, views.BackupInstancesOf(root)...)
+	for _, tag := range views.BackupTagsOf(root) {
+		b.Tags = append(b.Tags, apiBackupTag{Id: tag.Id, BackupTag: tag})
+	}
+
+	return b
+}
+
+func apiDRBackupOf(instances []fdb.DRBackupInstance, tags []fdb.DRBackupTag) apiDRBackup {
+	b := apiDRBackup{Instances: []fdb.DRBackupInstance{}, Tags: []apiDRBackupTag{}}
+
+	b.Instances = append(b.Instances, instances...)
+	for _, tag := range tags {
+		b.Tags = append(b.Tags, apiDRBackupTag{Id: tag.Id, DRBackupTag: tag})
+	}
+
+	return b
+}
+
+func apiDROf(root fdb.Root) apiDR {
+	return apiDR{
+		Source:      apiDRBackupOf(views.DrBackupInstancesOf(root), views.DrBackupTagsOf(root)),
+		Destination: apiDRBackupOf(views.DrBackupDestInstancesOf(root), views.DrBackupDestTagsOf(root)),
+	}
+}
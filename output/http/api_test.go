@@ -0,0 +1,65 @@
+package http
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"testing"
+)
+
+func TestServeAPIProcesses(t *testing.T) {
+	c := newPassiveCache(false)
+	c.publish([]byte(`{"cluster":{"processes":{
+		"a":{"address":"10.0.0.1:4500","class_type":"storage","roles":[{"role":"storage"}],"locality":{"dcid":"dc1"}},
+		"b":{"address":"10.0.0.2:4500","class_type":"log","roles":[{"role":"log"}],"locality":{"dcid":"dc2"}},
+		"c":{"address":"10.0.0.3:4500","class_type":"stateless","roles":[{"role":"commit_proxy"}],"locality":{"dcid":"dc1"}}
+	}}}`))
+
+	h := &HTTP{cache: c}
+
+	tests := []struct {
+		name      string
+		query     string
+		expected  int
+		addresses []string
+	}{
+		{name: "all", query: "", expected: http.StatusOK, addresses: []string{"10.0.0.1:4500", "10.0.0.2:4500", "10.0.0.3:4500"}},
+		{name: "role", query: "role=storage&role=log", expected: http.StatusOK, addresses: []string{"10.0.0.1:4500", "10.0.0.2:4500"}},
+		{name: "class", query: "class=stateless", expected: http.StatusOK, addresses: []string{"10.0.0.3:4500"}},
+		{name: "locality", query: "dc=dc1&sort=-address", expected: http.StatusOK, addresses: []string{"10.0.0.3:4500", "10.0.0.1:4500"}},
+		{name: "no matches", query: "role=resolver", expected: http.StatusOK, addresses: []string{}},
+		{name: "unknown role", query: "role=stroage", expected: http.StatusBadRequest},
+		{name: "unknown class", query: "class=storag", expected: http.StatusBadRequest},
+		{name: "unknown sort", query: "sort=latency", expected: http.StatusBadRequest},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest("GET", "/api/v1/processes?"+test.query, nil))
+
+			if w.Code != test.expected {
+				t.Fatalf("expected status %d, got %d: %s", test.expected, w.Code, w.Body.String())
+			}
+
+			if test.expected != http.StatusOK {
+				return
+			}
+
+			var processes []apiProcess
+			if err := json.Unmarshal(w.Body.Bytes(), &processes); err != nil {
+				t.Fatal(err)
+			}
+
+			addresses := []string{}
+			for _, p := range processes {
+				addresses = append(addresses, p.Address)
+			}
+
+			if !reflect.DeepEqual(addresses, test.addresses) {
+				t.Errorf("expected %v, got %v", test.addresses, addresses)
+			}
+		})
+	}
+}
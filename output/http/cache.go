@@ -0,0 +1,262 @@
+package http
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/pwood/fdbexplorer/input"
+)
+
+type entry struct {
+	data    []byte
+	etag    string
+	fetched time.Time
+
+	gzipOnce sync.Once
+	gzipped  []byte
+	gzipErr  error
+}
+
+func newEntry(d []byte, fetched time.Time) *entry {
+	sum := sha256.Sum256(d)
+	return &entry{
+		data:    d,
+		etag:    fmt.Sprintf("\"%s\"", hex.EncodeToString(sum[:16])),
+		fetched: fetched,
+	}
+}
+
+func (e *entry) gzip() ([]byte, error) {
+	e.gzipOnce.Do(func() {
+		var buf bytes.Buffer
+
+		zw := gzip.NewWriter(&buf)
+		if _, err := zw.Write(e.data); err != nil {
+			e.gzipErr = err
+			return
+		}
+
+		if err := zw.Close(); err != nil {
+			e.gzipErr = err
+			return
+		}
+
+		e.gzipped = buf.Bytes()
+	})
+
+	return e.gzipped, e.gzipErr
+}
+
+type call struct {
+	done  chan struct{}
+	entry *entry
+	err   error
+}
+
+var errNoStatus = errors.New("no status has been published yet")
+
+type cache struct {
+	ds           input.StatusProvider
+	ttl          time.Duration
+	staleOnError bool
+	passive      bool
+
+	m           sync.Mutex
+	current     *entry
+	lastErr     error
+	inflight    *call
+	subscribers map[chan *entry]struct{}
+}
+
+func newCache(ds input.StatusProvider, ttl time.Duration, staleOnError bool) *cache {
+	return &cache{ds: ds, ttl: ttl, staleOnError: staleOnError}
+}
+
+func newPassiveCache(staleOnError bool) *cache {
+	return &cache{passive: true, staleOnError: staleOnError}
+}
+
+func (c *cache) publish(d []byte) {
+	e := newEntry(d, time.Now())
+
+	c.m.Lock()
+	c.store(e)
+	c.lastErr = nil
+	c.m.Unlock()
+}
+
+func (c *cache) fail(err error) {
+	c.m.Lock()
+	c.lastErr = err
+	c.m.Unlock()
+}
+
+func (c *cache) store(e *entry) {
+	previous := c.current
+	c.current = e
+
+	if previous != nil && previous.etag == e.etag {
+		return
+	}
+
+	for ch := range c.subscribers {
+		select {
+		case ch <- e:
+		default:
+			select {
+			case <-ch:
+			default:
+			}
+
+			select {
+			case ch <- e:
+			default:
+			}
+		}
+	}
+}
+
+func (c *cache) subscribe() (<-chan *entry, func()) {
+	ch := make(chan *entry, 1)
+
+	c.m.Lock()
+	if c.subscribers == nil {
+		c.subscribers = make(map[chan *entry]struct{})
+	}
+	c.subscribers[ch] = struct{}{}
+	c.m.Unlock()
+
+	return ch, func() {
+		c.m.Lock()
+		delete(c.subscribers, ch)
+		c.m.Unlock()
+	}
+}
+
+func (c *cache) poll(interval time.Duration) {
+	if c.passive {
+		return
+	}
+
+	for range time.Tick(interval) {
+		c.m.Lock()
+		subscribed := len(c.subscribers) > 0
+		c.m.Unlock()
+
+		if subscribed {
+			_, _, _ = c.get()
+		}
+	}
+}
+
+func (c *cache) get() (*entry, bool, error) {
+	c.m.Lock()
+
+	if c.passive {
+		e, err := c.current, c.lastErr
+		c.m.Unlock()
+
+		switch {
+		case err == nil && e == nil:
+			return nil, false, errNoStatus
+		case err == nil:
+			return e, false, nil
+		case c.staleOnError && e != nil:
+			return e, true, nil
+		default:
+			return nil, false, err
+		}
+	}
+
+	if c.current != nil && time.Since(c.current.fetched) < c.ttl {
+		e := c.current
+		c.m.Unlock()
+		return e, false, nil
+	}
+
+	cl := c.inflight
+	leader := cl == nil
+
+	if leader {
+		cl = &call{done: make(chan struct{})}
+		c.inflight = cl
+	}
+
+	c.m.Unlock()
+
+	if leader {
+		c.fetch(cl)
+	} else {
+		<-cl.done
+	}
+
+	if cl.err == nil {
+		return cl.entry, false, nil
+	}
+
+	c.m.Lock()
+	last := c.current
+	c.m.Unlock()
+
+	if c.staleOnError && last != nil {
+		return last, true, nil
+	}
+
+	return nil, false, cl.err
+}
+
+func (c *cache) reachable() error {
+	_, _, err := c.get()
+
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	if c.lastErr != nil {
+		return c.lastErr
+	}
+
+	return err
+}
+
+func (c *cache) age() (time.Duration, error) {
+	_, _, err := c.get()
+
+	e := c.latest()
+	if e == nil {
+		return 0, err
+	}
+
+	return time.Since(e.fetched), nil
+}
+
+func (c *cache) latest() *entry {
+	c.m.Lock()
+	defer c.m.Unlock()
+
+	return c.current
+}
+
+func (c *cache) fetch(cl *call) {
+	d, err := c.ds.Status()
+
+	c.m.Lock()
+
+	if err == nil {
+		c.store(newEntry(d, time.Now()))
+		cl.entry = c.current
+	} else {
+		cl.err = err
+	}
+	c.lastErr = err
+
+	c.inflight = nil
+	c.m.Unlock()
+
+	close(cl.done)
+}
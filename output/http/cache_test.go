@@ -0,0 +1,92 @@
+package http
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestPassiveCacheReachable(t *testing.T) {
+	unreachable := errors.New("connection refused")
+
+	tests := []struct {
+		name     string
+		steps    []func(*cache)
+		expected error
+	}{
+		{
+			name:     "nothing published",
+			expected: errNoStatus,
+		},
+		{
+			name:  "published",
+			steps: []func(*cache){func(c *cache) { c.publish([]byte(`{}`)) }},
+		},
+		{
+			name: "failed after publish",
+			steps: []func(*cache){
+				func(c *cache) { c.publish([]byte(`{}`)) },
+				func(c *cache) { c.fail(unreachable) },
+			},
+			expected: unreachable,
+		},
+		{
+			name: "published after failure",
+			steps: []func(*cache){
+				func(c *cache) { c.publish([]byte(`{}`)) },
+				func(c *cache) { c.fail(unreachable) },
+				func(c *cache) { c.publish([]byte(`{"a":1}`)) },
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newPassiveCache(false)
+			for _, step := range test.steps {
+				step(c)
+			}
+
+			if err := c.reachable(); !errors.Is(err, test.expected) {
+				t.Errorf("reachable() = %v, want %v", err, test.expected)
+			}
+		})
+	}
+}
+
+func TestPassiveCacheGet(t *testing.T) {
+	unreachable := errors.New("connection refused")
+
+	tests := []struct {
+		name         string
+		staleOnError bool
+		publish      bool
+		fail         bool
+		entry        bool
+		stale        bool
+		err          error
+	}{
+		{name: "nothing published", err: errNoStatus},
+		{name: "published", publish: true, entry: true},
+		{name: "failed before publish", fail: true, err: unreachable},
+		{name: "failed before publish serving stale", staleOnError: true, fail: true, err: unreachable},
+		{name: "failed after publish", publish: true, fail: true, err: unreachable},
+		{name: "failed after publish serving stale", staleOnError: true, publish: true, fail: true, entry: true, stale: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newPassiveCache(test.staleOnError)
+			if test.publish {
+				c.publish([]byte(`{}`))
+			}
+			if test.fail {
+				c.fail(unreachable)
+			}
+
+			e, stale, err := c.get()
+			if (e != nil) != test.entry || stale != test.stale || !errors.Is(err, test.err) {
+				t.Errorf("get() = %v, %t, %v, want entry %t, %t, %v", e, stale, err, test.entry, test.stale, test.err)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+package http
+
+import (
+	"fmt"
+	"net/http"
+	neturl "net/url"
+	"strings"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/input"
+)
+
+type cluster struct {
+	name  string
+	ds    input.StatusProvider
+	cache *cache
+}
+
+type apiCluster struct {
+	Name       string     `json:"name"`
+	StatusURL  string     `json:"status_url"`
+	StreamURL  string     `json:"stream_url"`
+	Healthy    bool       `json:"healthy"`
+	Reasons    []string   `json:"reasons"`
+	AgeSeconds float64    `json:"age_seconds"`
+	Health     *apiHealth `json:"health,omitempty"`
+}
+
+func openClusters(spec string) ([]*cluster, error) {
+	var clusters []*cluster
+	seen := make(map[string]bool)
+
+	if spec == "" {
+		return nil, nil
+	}
+
+	for _, entry := range strings.Split(spec, ",") {
+		name, source, ok := strings.Cut(strings.TrimSpace(entry), "=")
+		if !ok || name == "" || source == "" {
+			return nil, fmt.Errorf("clusters: expected name=source, got %q", entry)
+		}
+
+		if strings.Contains(name, "/") {
+			return nil, fmt.Errorf("clusters: name %q must not contain '/'", name)
+		}
+
+		if seen[name] {
+			return nil, fmt.Errorf("clusters: name %q is repeated", name)
+		}
+		seen[name] = true
+
+		ds, err := input.Open(source)
+		if err != nil {
+			return nil, fmt.Errorf("clusters: %s: %w", name, err)
+		}
+
+		clusters = append(clusters, &cluster{name: name, ds: ds, cache: newPassiveCache(*httpServeStale)})
+	}
+
+	return clusters, nil
+}
+
+func proxied(r *http.Request) bool {
+	return r.URL.Path == "/clusters" || strings.HasPrefix(r.URL.Path, "/clusters/") || probe(r)
+}
+
+func (c *cluster) reason(format string, a ...any) string {
+	if c.name == "" {
+		return fmt.Sprintf(format, a...)
+	}
+
+	return fmt.Sprintf("cluster '%s': %s", c.name, fmt.Sprintf(format, a...))
+}
+
+func (c *cluster) poll(interval time.Duration) {
+	for {
+		if d, err := c.ds.Status(); err != nil {
+			c.cache.fail(err)
+		} else {
+			c.cache.publish(d)
+		}
+
+		time.Sleep(interval)
+	}
+}
+
+func (c *cluster) summary() apiCluster {
+	prefix := "/clusters/" + neturl.PathEscape(c.name)
+	s := apiCluster{Name: c.name, StatusURL: prefix + "/status/json", StreamURL: prefix + "/status/stream", Reasons: []string{}}
+
+	if err := c.cache.reachable(); err != nil {
+		s.Reasons = append(s.Reasons, fmt.Sprintf("status source unreachable: %s", err.Error()))
+	}
+
+	e := c.cache.latest()
+	if e == nil {
+		return s
+	}
+
+	s.AgeSeconds = time.Since(e.fetched).Seconds()
+
+	root, err := fdb.Decode(e.data)
+	if err != nil {
+		s.Reasons = append(s.Reasons, fmt.Sprintf("status undecodable: %s", err.Error()))
+		return s
+	}
+
+	health := apiHealthOf(root)
+	s.Health = &health
+	s.Reasons = append(s.Reasons, clusterHealthOf(root).Reasons...)
+	s.Healthy = len(s.Reasons) == 0
+
+	return s
+}
+
+func (h *HTTP) serveClusters(w http.ResponseWriter) {
+	body := make([]apiCluster, 0, len(h.clusters))
+	for _, c := range h.clusters {
+		body = append(body, c.summary())
+	}
+
+	writeJSON(w, http.StatusOK, body)
+}
+
+func (h *HTTP) serveCluster(w http.ResponseWriter, r *http.Request) {
+	name, endpoint, _ := strings.Cut(strings.TrimPrefix(r.URL.Path, "/clusters/"), "/")
+
+	for _, c := range h.clusters {
+		if c.name != name {
+			continue
+		}
+
+		switch endpoint {
+		case "status/json":
+			serveStatus(w, r, c.cache)
+		case "status/stream":
+			serveStream(w, r, c.cache)
+		default:
+			http.NotFound(w, r)
+		}
+
+		return
+	}
+
+	http.NotFound(w, r)
+}
@@ -0,0 +1,21 @@
+package http
+
+import (
+	"embed"
+	"io/fs"
+	"net/http"
+)
+
+//go:embed dashboard
+var dashboardFiles embed.FS
+
+const dashboardPath = "/dashboard/"
+
+func dashboardHandler() http.Handler {
+	sub, err := fs.Sub(dashboardFiles, "dashboard")
+	if err != nil {
+		panic(err)
+	}
+
+	return http.StripPrefix(dashboardPath, http.FileServer(http.FS(sub)))
+}
@@ -0,0 +1,223 @@
+"use strict";
+
+const units = ["B", "KiB", "MiB", "GiB", "TiB"];
+
+function bytes(v) {
+  let i = 0;
+  while (Math.abs(v) >= 1024 && i < units.length - 1) {
+    v /= 1024;
+    i++;
+  }
+  return v.toFixed(1) + " " + units[i];
+}
+
+function percent(v) {
+  return (v * 100).toFixed(1) + "%";
+}
+
+function seconds(v) {
+  return v.toFixed(3) + "s";
+}
+
+function millis(v) {
+  return (v * 1000).toFixed(2) + "ms";
+}
+
+function rate(v) {
+  return v.toFixed(1) + "/s";
+}
+
+function uptime(v) {
+  const d = Math.floor(v / 86400), h = Math.floor(v % 86400 / 3600), m = Math.floor(v % 3600 / 60);
+  return (d > 0 ? d + "d" : "") + h + "h" + m + "m";
+}
+
+function yesNo(b) {
+  return b ? "Yes" : "No";
+}
+
+function titlify(s) {
+  return (s || "").split("_").map(w => w.charAt(0).toUpperCase() + w.slice(1)).join(" ");
+}
+
+function escape(s) {
+  return String(s).replace(/[&<>"']/g, c => ({"&": "&amp;", "<": "&lt;", ">": "&gt;", "\"": "&quot;", "'": "&#39;"}[c]));
+}
+
+function stats(el, items) {
+  el.innerHTML = items.map(([name, value, cls]) =>
+    `<dt>${escape(name)}</dt><dd class="${cls || ""}">${escape(value)}</dd>`).join("");
+}
+
+function table(columns, rows, rowClass) {
+  const head = columns.map(c => `<th>${escape(c[0])}</th>`).join("");
+  const body = (rows || []).map(r =>
+    `<tr class="${rowClass ? rowClass(r) : ""}">` + columns.map(c => `<td>${escape(c[1](r))}</td>`).join("") + "</tr>").join("");
+  return `<table><thead><tr>${head}</tr></thead><tbody>${body}</tbody></table>`;
+}
+
+const processColumns = {
+  address: ["IP Address:Port", p => p.address],
+  tls: ["TLS", p => p.tls ? "✓" : ""],
+  status: ["Status", p => p.status],
+  machine: ["Machine", p => p.locality.machineid || ""],
+  locality: ["Locality", p => `${p.locality.data_hall || ""} / ${p.locality.dcid || ""}`],
+  class: ["Class", p => p.class],
+  roles: ["Roles", p => p.roles.join(", ")],
+  version: ["Version", p => p.version],
+  uptime: ["Uptime", p => uptime(p.uptime_seconds)],
+  cpu: ["CPU Activity", p => percent(p.cpu_cores)],
+  ram: ["RAM Usage", p => `${percent(p.memory_rss_bytes / p.memory_available_bytes)} (${bytes(p.memory_rss_bytes)} of ${bytes(p.memory_available_bytes)})`],
+  network: ["Network Activity", p => `${p.network_sent_mbps.toFixed(1)} Mbps / ${p.network_received_mbps.toFixed(1)} Mbps`],
+  disk: ["Disk Usage", p => {
+    const used = p.disk_total_bytes - p.disk_free_bytes;
+    return `${percent(used / p.disk_total_bytes)} (${bytes(used)} of ${bytes(p.disk_total_bytes)})`;
+  }],
+  diskBusy: ["Disk Busy", p => percent(p.disk_busy)],
+  kv: ["KV Storage", p => p.storage ? bytes(p.storage.kv_used_bytes) : ""],
+  storageQueue: ["Queue Length", p => p.storage ? bytes(p.storage.queue_bytes) : ""],
+  lag: ["Data / Durability Lag", p => p.storage ? `${seconds(p.storage.data_lag_seconds)} / ${seconds(p.storage.durability_lag_seconds)}` : ""],
+  logQueue: ["Queue Length", p => p.log ? bytes(p.log.queue_bytes) : ""],
+  logDisk: ["Queue Storage", p => p.log ? bytes(p.log.queue_disk_used_bytes) : ""],
+};
+
+function columns(...names) {
+  return names.map(n => processColumns[n]);
+}
+
+function processClass(p) {
+  return p.health === "normal" ? "" : p.health;
+}
+
+const tabs = {
+  locality: {
+    api: "processes",
+    render: d => table(columns("address", "tls", "status", "machine", "locality", "class", "roles", "version", "uptime"), d, processClass),
+  },
+  usage: {
+    api: "processes",
+    render: d => table(columns("address", "roles", "cpu", "ram", "network", "disk", "diskBusy"), d, processClass),
+  },
+  storage: {
+    api: "processes?role=storage",
+    render: d => table(columns("address", "cpu", "ram", "disk", "diskBusy", "kv", "storageQueue", "lag"), d, processClass),
+  },
+  logs: {
+    api: "processes?role=log",
+    render: d => table(columns("address", "cpu", "ram", "disk", "diskBusy", "logQueue", "logDisk"), d, processClass),
+  },
+  backups: {
+    api: "backups",
+    render: d => "<h3>Instances</h3>" + table([
+      ["Id", i => i.id],
+      ["Version", i => i.version],
+      ["Configured Workers", i => i.configured_workers],
+      ["RSS", i => bytes(i.resident_size)],
+      ["Recent Transfer", i => `${bytes(i.blob_stats.recent.bytes_per_second)}/s`],
+      ["Recent Operations", i => `${i.blob_stats.recent.requests_successful} Succeeded / ${i.blob_stats.recent.requests_failed} Failed`],
+    ], d.instances) + "<h3>Tags</h3>" + table([
+      ["Tag", t => t.id],
+      ["Status", t => t.current_status],
+      ["Running", t => yesNo(t.running_backup)],
+      ["Restorable", t => yesNo(t.running_backup_is_restorable)],
+      ["Seconds Behind", t => t.last_restorable_seconds_behind.toFixed(1)],
+      ["Container", t => t.current_container],
+    ], d.tags),
+  },
+  dr: {
+    api: "dr",
+    render: d => ["source", "destination"].map(side => `<h3>${titlify(side)} Instances</h3>` + table([
+      ["Id", i => i.id],
+      ["Version", i => i.version],
+      ["Configured Workers", i => i.configured_workers],
+      ["RSS", i => bytes(i.resident_size)],
+      ["CPU", i => `${i.process_cpu_seconds.toFixed(0)}s`],
+    ], d[side].instances) + `<h3>${titlify(side)} Tags</h3>` + table([
+      ["Tag", t => t.id],
+      ["State", t => t.backup_state],
+      ["Running", t => yesNo(t.running_backup)],
+      ["Restorable", t => yesNo(t.running_backup_is_restorable)],
+      ["Seconds Behind", t => t.seconds_behind.toFixed(1)],
+    ], d[side].tags)).join(""),
+  },
+};
+
+let active = "locality";
+let timer = null;
+
+async function api(path) {
+  const res = await fetch("../api/v1/" + path, {cache: "no-store"});
+  if (!res.ok) {
+    throw new Error(`${path}: ${res.status} ${await res.text()}`);
+  }
+  return res.json();
+}
+
+function renderHeader(h, w) {
+  const ft = h.fault_tolerance;
+  stats(document.getElementById("health"), [
+    ["Healthy", titlify(h.health), h.healthy ? "good" : "critical"],
+    ["Rebalance Queued", bytes(h.rebalance_queued_bytes)],
+    ["GRV Latency", millis(h.latency_probe.transaction_start_seconds)],
+    ["Replicas Remaining", h.min_replicas_remaining],
+    ["Rebalance In-flight", bytes(h.rebalance_in_flight_bytes)],
+    ["Read Latency", millis(h.latency_probe.read_seconds)],
+    ["Recovery State", titlify(h.recovery_state), h.recovery_state === "fully_recovered" ? "" : "warning"],
+    ["Zone Failures (Data / Avail)", `${ft.max_zone_failures_without_losing_data} / ${ft.max_zone_failures_without_losing_availability}`],
+    ["Commit Latency", millis(h.latency_probe.commit_seconds)],
+    ["Recovery Description", h.recovery_description],
+    ["Database Locked", yesNo(h.database_locked), h.database_locked ? "warning" : ""],
+    ["Redundancy Mode", h.redundancy_mode],
+  ]);
+
+  stats(document.getElementById("workload"), [
+    ["Tx Started", rate(w.transactions_started_per_second)],
+    ["Reads", rate(w.reads_per_second)],
+    ["Tx Committed", rate(w.transactions_committed_per_second)],
+    ["Writes", rate(w.writes_per_second)],
+    ["Tx Conflicted", rate(w.transactions_conflicted_per_second)],
+    ["Bytes Read", bytes(w.bytes_read_per_second) + "/s"],
+    ["Tx Rejected", rate(w.transactions_rejected_per_second)],
+    ["Bytes Written", bytes(w.bytes_written_per_second) + "/s"],
+  ]);
+}
+
+async function refresh() {
+  const status = document.getElementById("status");
+  const start = performance.now();
+
+  try {
+    const tab = tabs[active];
+    const [health, workload, data] = await Promise.all([api("health"), api("workload"), api(tab.api)]);
+
+    renderHeader(health, workload);
+    document.getElementById("content").innerHTML = tab.render(data);
+
+    status.className = "good";
+    status.textContent = `[${new Date().toLocaleTimeString()}] Updated in ${Math.round(performance.now() - start)}ms.`;
+  } catch (e) {
+    status.className = "critical";
+    status.textContent = `[${new Date().toLocaleTimeString()}] Failed to update: ${e.message}`;
+  }
+}
+
+function schedule() {
+  clearInterval(timer);
+  timer = setInterval(refresh, Number(document.getElementById("interval").value));
+}
+
+document.getElementById("tabs").addEventListener("click", e => {
+  const tab = e.target.dataset.tab;
+  if (!tab) {
+    return;
+  }
+
+  active = tab;
+  document.querySelectorAll("#tabs button").forEach(b => b.classList.toggle("active", b.dataset.tab === tab));
+  refresh();
+});
+
+document.getElementById("interval").addEventListener("change", schedule);
+
+refresh();
+schedule();
@@ -0,0 +1,126 @@
+package http
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+const (
+	metricGauge   = "gauge"
+	metricCounter = "counter"
+)
+
+type label struct {
+	name  string
+	value string
+}
+
+type sample struct {
+	labels []label
+	value  float64
+}
+
+type family struct {
+	name    string
+	kind    string
+	help    string
+	samples []sample
+}
+
+type registry struct {
+	order    []string
+	families map[string]*family
+}
+
+func newRegistry() *registry {
+	return &registry{families: make(map[string]*family)}
+}
+
+func (r *registry) add(name, kind, help string, value float64, labels ...label) {
+	f, ok := r.families[name]
+	if !ok {
+		f = &family{name: name, kind: kind, help: help}
+		r.families[name] = f
+		r.order = append(r.order, name)
+	}
+
+	f.samples = append(f.samples, sample{labels: labels, value: value})
+}
+
+func (r *registry) gauge(name, help string, value float64, labels ...label) {
+	r.add(name, metricGauge, help, value, labels...)
+}
+
+func (r *registry) counter(name, help string, value float64, labels ...label) {
+	r.add(name, metricCounter, help, value, labels...)
+}
+
+func (r *registry) WriteTo(w io.Writer) (int64, error) {
+	var sb strings.Builder
+
+	for _, name := range r.order {
+		f := r.families[name]
+
+		_, _ = fmt.Fprintf(&sb, "# HELP %s %s\n", f.name, escapeHelp(f.help))
+		_, _ = fmt.Fprintf(&sb, "# TYPE %s %s\n", f.name, f.kind)
+
+		sort.SliceStable(f.samples, func(i, j int) bool {
+			return labelString(f.samples[i].labels) < labelString(f.samples[j].labels)
+		})
+
+		for _, s := range f.samples {
+			_, _ = fmt.Fprintf(&sb, "%s%s %s\n", f.name, labelString(s.labels), formatValue(s.value))
+		}
+	}
+
+	n, err := io.WriteString(w, sb.String())
+	return int64(n), err
+}
+
+func labelString(labels []label) string {
+	if len(labels) == 0 {
+		return ""
+	}
+
+	parts := make([]string, len(labels))
+	for i, l := range labels {
+		parts[i] = fmt.Sprintf("%s=\"%s\"", l.name, escapeLabel(l.value))
+	}
+
+	return "{" + strings.Join(parts, ",") + "}"
+}
+
+var labelEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+var helpEscaper = strings.NewReplacer(`\`, `\\`, "\n", `\n`)
+
+func escapeLabel(s string) string {
+	return labelEscaper.Replace(s)
+}
+
+func escapeHelp(s string) string {
+	return helpEscaper.Replace(s)
+}
+
+func formatValue(v float64) string {
+	switch {
+	case math.IsInf(v, 1):
+		return "+Inf"
+	case math.IsInf(v, -1):
+		return "-Inf"
+	case math.IsNaN(v):
+		return "NaN"
+	default:
+		return strconv.FormatFloat(v, 'g', -1, 64)
+	}
+}
+
+func boolValue(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,107 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+type healthCheck struct {
+	Healthy bool     `json:"healthy"`
+	Reasons []string `json:"reasons"`
+}
+
+func (h *HTTP) serveCheck(w http.ResponseWriter, hc healthCheck) {
+	code := http.StatusOK
+	if !hc.Healthy {
+		code = http.StatusServiceUnavailable
+	}
+
+	if hc.Reasons == nil {
+		hc.Reasons = []string{}
+	}
+
+	w.Header().Add("content-type", "application/json")
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(hc)
+}
+
+// probed returns the status sources behind /healthz and /readyz, every
+// configured cluster when only proxying clusters.
+func (h *HTTP) probed() []*cluster {
+	if h.proxy {
+		return h.clusters
+	}
+
+	return []*cluster{{cache: h.cache}}
+}
+
+func (h *HTTP) serveHealthz(w http.ResponseWriter) {
+	var reasons []string
+
+	for _, c := range h.probed() {
+		if err := c.cache.reachable(); err != nil {
+			reasons = append(reasons, c.reason("status source unreachable: %s", err.Error()))
+		}
+	}
+
+	h.serveCheck(w, healthCheck{Healthy: len(reasons) == 0, Reasons: reasons})
+}
+
+func (h *HTTP) serveReadyz(w http.ResponseWriter) {
+	var reasons []string
+
+	for _, c := range h.probed() {
+		age, err := c.cache.age()
+		if err != nil {
+			reasons = append(reasons, c.reason("no status cached: %s", err.Error()))
+		} else if age > *httpReadyMaxAge {
+			reasons = append(reasons, c.reason("cached status is %s old, maximum is %s", age.Truncate(time.Second), *httpReadyMaxAge))
+		}
+	}
+
+	h.serveCheck(w, healthCheck{Healthy: len(reasons) == 0, Reasons: reasons})
+}
+
+func (h *HTTP) serveClusterHealth(w http.ResponseWriter) {
+	e, _, err := h.cache.get()
+	if err != nil {
+		statusError(w, err)
+		return
+	}
+
+	root, err := fdb.Decode(e.data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	h.serveCheck(w, clusterHealthOf(root))
+}
+
+func clusterHealthOf(root fdb.Root) healthCheck {
+	var reasons []string
+	c := root.Cluster
+
+	if *httpHealthAvailable && !c.DatabaseAvailable {
+		reasons = append(reasons, "database is unavailable")
+	}
+
+	if *httpHealthDataHealthy && !c.Data.State.Health {
+		reasons = append(reasons, fmt.Sprintf("data state is '%s'", c.Data.State.Name))
+	}
+
+	if c.Data.State.MinReplicasRemaining < *httpHealthMinReplicas {
+		reasons = append(reasons, fmt.Sprintf("%d replicas remaining, minimum is %d", c.Data.State.MinReplicasRemaining, *httpHealthMinReplicas))
+	}
+
+	if *httpHealthRecovered && c.RecoveryState.Name != fdb.RecoveryStateFullyRecovered {
+		reasons = append(reasons, fmt.Sprintf("recovery in progress, state is '%s'", c.RecoveryState.Name))
+	}
+
+	return healthCheck{Healthy: len(reasons) == 0, Reasons: reasons}
+}
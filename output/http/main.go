@@ -1,17 +1,62 @@
 package http
 
 import (
+	"crypto/tls"
+	"errors"
 	"flag"
+	"fmt"
+	"github.com/pwood/fdbexplorer/data/fdb"
 	"github.com/pwood/fdbexplorer/input"
+	"net"
 	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
 )
 
 var httpEnable *bool
 var httpAddress *string
+var httpCacheTTL *time.Duration
+var httpServeStale *bool
+var httpWithUI *bool
+var httpStreamInterval *time.Duration
+var httpTLSCert *string
+var httpTLSKey *string
+var httpTLSClientCA *string
+var httpTokenFile *string
+var httpBasicAuthFile *string
+var httpAllow *string
+var httpWriteTokenFile *string
+var httpClusters *string
+var httpClustersInterval *time.Duration
+var httpReadyMaxAge *time.Duration
+var httpHealthAvailable *bool
+var httpHealthDataHealthy *bool
+var httpHealthMinReplicas *int
+var httpHealthRecovered *bool
 
 func init() {
-	httpEnable = flag.Bool("http-enable", false, "If the http output should be enabled, making the `status json` output available on /status/json.")
-	httpAddress = flag.String("http-address", "127.0.0.1:8080", "Host and port number for http server to listen on, using 0.0.0.0 for all interface bind.")
+	httpEnable = flag.Bool("http-enable", false, "If the http output should be enabled, making the `status json` output available on /status/json and Prometheus metrics on /metrics.")
+	httpAddress = flag.String("http-address", "127.0.0.1:8080", "Host and port number for http server to listen on, using 0.0.0.0 for all interface bind, or unix:<path> for a Unix domain socket.")
+	httpCacheTTL = flag.Duration("http-cache-ttl", time.Second, "How long a fetched 'status json' is served to http clients before it is fetched again, concurrent fetches are always coalesced.")
+	httpWithUI = flag.Bool("http-with-ui", false, "Run the http output alongside the TUI, publishing the data the TUI polls rather than polling separately.")
+	httpTLSCert = flag.String("http-tls-cert", "", "Location of a PEM certificate to serve http over TLS, requires -http-tls-key.")
+	httpTLSKey = flag.String("http-tls-key", "", "Location of the PEM private key for -http-tls-cert.")
+	httpTLSClientCA = flag.String("http-tls-client-ca", "", "Location of a PEM CA bundle, http clients must present a certificate signed by it.")
+	httpTokenFile = flag.String("http-token-file", "", "Location of a file containing a bearer token that http clients must present.")
+	httpBasicAuthFile = flag.String("http-basic-auth-file", "", "Location of a file of user:password lines that http clients may authenticate with.")
+	httpWriteTokenFile = flag.String("http-write-token-file", "", "Location of a file containing a bearer token that http clients must present to manage exclusions and maintenance zones, disabled if empty.")
+	httpAllow = flag.String("http-allow", "", "Comma separated list of IP addresses or CIDRs allowed to connect to the http server, all if empty.")
+	httpStreamInterval = flag.Duration("http-stream-interval", 5*time.Second, "How often 'status json' is fetched for /status/stream subscribers, when not running alongside the TUI.")
+	httpReadyMaxAge = flag.Duration("http-ready-max-age", 30*time.Second, "Age of the cached 'status json' beyond which /readyz reports not ready.")
+	httpHealthAvailable = flag.Bool("http-health-available", true, "If /cluster/health requires the database to be available.")
+	httpHealthDataHealthy = flag.Bool("http-health-data-healthy", true, "If /cluster/health requires the data distribution state to be healthy.")
+	httpHealthMinReplicas = flag.Int("http-health-min-replicas", 1, "Minimum replicas remaining that /cluster/health requires.")
+	httpHealthRecovered = flag.Bool("http-health-recovered", true, "If /cluster/health requires the cluster to be fully recovered.")
+	httpClusters = flag.String("http-clusters", "", "Comma separated list of name=source clusters to poll and serve under /clusters, where source is a status json URL, file:<path> or a cluster file.")
+	httpClustersInterval = flag.Duration("http-clusters-interval", 10*time.Second, "How often each of -http-clusters is polled.")
+	httpServeStale = flag.Bool("http-serve-stale", false, "If fetching 'status json' fails, serve the last good copy to http clients with an Age header instead of an error.")
 }
 
 func NewHTTP(ds input.StatusProvider) (*HTTP, bool) {
@@ -19,31 +64,262 @@ func NewHTTP(ds input.StatusProvider) (*HTTP, bool) {
 		return nil, false
 	}
 
-	return &HTTP{ds: ds, address: *httpAddress}, true
+	h := &HTTP{address: *httpAddress, dashboard: dashboardHandler(), proxy: ds == nil}
+
+	if *httpWithUI || ds == nil {
+		h.cache = newPassiveCache(*httpServeStale)
+	} else {
+		h.cache = newCache(ds, *httpCacheTTL, *httpServeStale)
+	}
+
+	if em, ok := ds.(input.ExclusionManager); ok {
+		h.em = em
+	}
+
+	if mm, ok := ds.(input.MaintenanceManager); ok {
+		h.mm = mm
+	}
+
+	return h, true
+}
+
+func Proxying() bool {
+	return *httpEnable && *httpClusters != "" && !*httpWithUI
+}
+
+func WithUI() bool {
+	return *httpWithUI
 }
 
 type HTTP struct {
-	ds      input.StatusProvider
-	address string
+	address   string
+	cache     *cache
+	handler   http.Handler
+	dashboard http.Handler
+	tls       *tls.Config
+	em        input.ExclusionManager
+	mm        input.MaintenanceManager
+	clusters  []*cluster
+	proxy     bool
 }
 
 func (h *HTTP) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	if r.URL.Path == "/status/json" && r.Method == "GET" {
-		if d, err := h.ds.Status(); err != nil {
-			http.Error(w, err.Error(), http.StatusInternalServerError)
+	if allow := allowedMethods(r.URL.Path); !slices.Contains(allow, r.Method) {
+		w.Header().Set("Allow", strings.Join(allow, ", "))
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+
+	if h.proxy && !proxied(r) {
+		http.NotFound(w, r)
+		return
+	}
+
+	switch r.URL.Path {
+	case "/status/json":
+		serveStatus(w, r, h.cache)
+	case "/status/stream":
+		serveStream(w, r, h.cache)
+	case "/metrics":
+		h.serveMetrics(w)
+	case "/healthz":
+		h.serveHealthz(w)
+	case "/readyz":
+		h.serveReadyz(w)
+	case "/cluster/health":
+		h.serveClusterHealth(w)
+	case "/api/v1/exclusions":
+		h.serveExclusions(w, r)
+	case "/api/v1/maintenance":
+		h.serveMaintenance(w, r)
+	case "/clusters":
+		h.serveClusters(w)
+	case "/":
+		http.Redirect(w, r, dashboardPath, http.StatusFound)
+	default:
+		if strings.HasPrefix(r.URL.Path, "/api/v1/") {
+			h.serveAPI(w, r)
+		} else if strings.HasPrefix(r.URL.Path, dashboardPath) {
+			h.dashboard.ServeHTTP(w, r)
+		} else if strings.HasPrefix(r.URL.Path, "/clusters/") {
+			h.serveCluster(w, r)
 		} else {
-			w.Header().Add("content-type", "application/json")
-			w.WriteHeader(http.StatusOK)
-			_, _ = w.Write(d)
+			http.NotFound(w, r)
 		}
+	}
+}
+
+func (h *HTTP) Publish(d []byte) {
+	h.cache.publish(d)
+}
+
+func (h *HTTP) Fail(err error) {
+	h.cache.fail(err)
+}
+
+func statusError(w http.ResponseWriter, err error) {
+	if errors.Is(err, errNoStatus) {
+		http.Error(w, err.Error(), http.StatusServiceUnavailable)
 	} else {
-		http.NotFound(w, r)
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
+func serveStatus(w http.ResponseWriter, r *http.Request, c *cache) {
+	e, stale, err := c.get()
+	if err != nil {
+		statusError(w, err)
+		return
+	}
+
+	w.Header().Set("ETag", e.etag)
+	w.Header().Set("Last-Modified", e.fetched.UTC().Format(http.TimeFormat))
+	w.Header().Set("Age", fmt.Sprintf("%d", int(time.Since(e.fetched).Seconds())))
+	w.Header().Set("Vary", "Accept-Encoding")
+
+	if stale {
+		w.Header().Set("Warning", `110 - "Response is Stale"`)
+	}
+
+	if notModified(r, e) {
+		w.WriteHeader(http.StatusNotModified)
+		return
 	}
+
+	d := e.data
+
+	if acceptsGzip(r) {
+		if gz, err := e.gzip(); err == nil {
+			d = gz
+			w.Header().Set("Content-Encoding", "gzip")
+		}
+	}
+
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(d)
+}
+
+func notModified(r *http.Request, e *entry) bool {
+	if inm := r.Header.Get("If-None-Match"); inm != "" {
+		for _, tag := range strings.Split(inm, ",") {
+			if tag = strings.TrimSpace(tag); tag == e.etag || tag == "*" {
+				return true
+			}
+		}
+		return false
+	}
+
+	if ims := r.Header.Get("If-Modified-Since"); ims != "" {
+		if t, err := http.ParseTime(ims); err == nil {
+			return !e.fetched.Truncate(time.Second).After(t)
+		}
+	}
+
+	return false
+}
+
+// acceptsGzip reports if the Accept-Encoding header permits gzip, an explicit
+// gzip entry takes precedence over a wildcard and a zero q-value refuses.
+func acceptsGzip(r *http.Request) bool {
+	gzipQ, wildcardQ := -1.0, -1.0
+
+	for _, value := range r.Header.Values("Accept-Encoding") {
+		for _, token := range strings.Split(value, ",") {
+			coding, params, _ := strings.Cut(token, ";")
+			q := 1.0
+
+			for _, param := range strings.Split(params, ";") {
+				if k, v, ok := strings.Cut(strings.TrimSpace(param), "="); ok && strings.EqualFold(k, "q") {
+					if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+						q = parsed
+					} else {
+						q = 0
+					}
+				}
+			}
+
+			switch strings.ToLower(strings.TrimSpace(coding)) {
+			case "gzip", "x-gzip":
+				gzipQ = q
+			case "*":
+				wildcardQ = q
+			}
+		}
+	}
+
+	if gzipQ >= 0 {
+		return gzipQ > 0
+	}
+
+	return wildcardQ > 0
+}
+
+func (h *HTTP) serveMetrics(w http.ResponseWriter) {
+	e, _, err := h.cache.get()
+	if err != nil {
+		statusError(w, err)
+		return
+	}
+
+	root, err := fdb.Decode(e.data)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Add("content-type", "text/plain; version=0.0.4; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	_, _ = collectMetrics(root).WriteTo(w)
+}
+
+func (h *HTTP) Listen() (net.Listener, error) {
+	sec, err := loadSecurity()
+	if err != nil {
+		return nil, err
+	}
+
+	if h.tls, err = loadTLS(); err != nil {
+		return nil, err
+	}
+
+	if h.clusters, err = openClusters(*httpClusters); err != nil {
+		return nil, err
+	}
+
+	ln, unix, err := listen(h.address)
+	if err != nil {
+		return nil, err
+	}
+
+	h.handler = sec.wrap(h, unix)
+
+	return ln, nil
+}
+
+func (h *HTTP) Serve(ln net.Listener) error {
+	server := &http.Server{Handler: h.handler, TLSConfig: h.tls}
+
+	go h.cache.poll(*httpStreamInterval)
+
+	for _, c := range h.clusters {
+		go c.poll(*httpClustersInterval)
+	}
+
+	if h.tls != nil {
+		return server.ServeTLS(ln, "", "")
+	}
+
+	return server.Serve(ln)
 }
 
 func (h *HTTP) Run() {
-	err := http.ListenAndServe(h.address, h)
+	ln, err := h.Listen()
 	if err != nil {
 		panic(err)
 	}
+
+	if err := h.Serve(ln); err != nil {
+		panic(err)
+	}
 }
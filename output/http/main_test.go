@@ -0,0 +1,125 @@
+package http
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestNotModified(t *testing.T) {
+	fetched := time.Date(2024, 1, 1, 12, 0, 0, 500_000_000, time.UTC)
+	e := newEntry([]byte(`{"cluster":{}}`), fetched)
+
+	tests := []struct {
+		name     string
+		headers  map[string]string
+		expected bool
+	}{
+		{name: "no conditions"},
+		{name: "matching etag", headers: map[string]string{"If-None-Match": e.etag}, expected: true},
+		{name: "matching etag in list", headers: map[string]string{"If-None-Match": `"other", ` + e.etag}, expected: true},
+		{name: "wildcard etag", headers: map[string]string{"If-None-Match": "*"}, expected: true},
+		{name: "different etag", headers: map[string]string{"If-None-Match": `"other"`}},
+		{
+			name:    "etag takes precedence over modified since",
+			headers: map[string]string{"If-None-Match": `"other"`, "If-Modified-Since": fetched.Add(time.Hour).Format(http.TimeFormat)},
+		},
+		{name: "modified since same second", headers: map[string]string{"If-Modified-Since": fetched.Format(http.TimeFormat)}, expected: true},
+		{name: "modified since later", headers: map[string]string{"If-Modified-Since": fetched.Add(time.Minute).Format(http.TimeFormat)}, expected: true},
+		{name: "modified since earlier", headers: map[string]string{"If-Modified-Since": fetched.Add(-time.Minute).Format(http.TimeFormat)}},
+		{name: "invalid modified since", headers: map[string]string{"If-Modified-Since": "yesterday"}},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/status/json", nil)
+			for k, v := range test.headers {
+				r.Header.Set(k, v)
+			}
+
+			if actual := notModified(r, e); actual != test.expected {
+				t.Errorf("notModified() = %t, want %t", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestAcceptsGzip(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		expected bool
+	}{
+		{name: "no header"},
+		{name: "gzip", header: "gzip", expected: true},
+		{name: "gzip in list", header: "deflate, gzip, br", expected: true},
+		{name: "case insensitive", header: "GZIP", expected: true},
+		{name: "x-gzip", header: "x-gzip", expected: true},
+		{name: "positive q-value", header: "gzip;q=0.5", expected: true},
+		{name: "zero q-value", header: "gzip;q=0"},
+		{name: "zero q-value with spaces", header: "br, gzip ; q=0.000"},
+		{name: "invalid q-value", header: "gzip;q=high"},
+		{name: "wildcard", header: "*", expected: true},
+		{name: "refused wildcard", header: "*;q=0"},
+		{name: "gzip overrides refused wildcard", header: "gzip, *;q=0", expected: true},
+		{name: "refused gzip overrides wildcard", header: "gzip;q=0, *"},
+		{name: "identity only", header: "identity"},
+		{name: "substring", header: "notgzip"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/status/json", nil)
+			if test.header != "" {
+				r.Header.Set("Accept-Encoding", test.header)
+			}
+
+			if actual := acceptsGzip(r); actual != test.expected {
+				t.Errorf("acceptsGzip() = %t, want %t", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestServeHTTPMethods(t *testing.T) {
+	c := newPassiveCache(false)
+	c.publish([]byte(`{"cluster":{}}`))
+
+	h := &HTTP{cache: c, dashboard: dashboardHandler()}
+
+	tests := []struct {
+		method   string
+		path     string
+		expected int
+		allow    string
+	}{
+		{method: "GET", path: "/status/json", expected: http.StatusOK},
+		{method: "HEAD", path: "/status/json", expected: http.StatusOK},
+		{method: "HEAD", path: "/status/stream", expected: http.StatusOK},
+		{method: "HEAD", path: "/healthz", expected: http.StatusOK},
+		{method: "HEAD", path: "/readyz", expected: http.StatusOK},
+		{method: "HEAD", path: "/metrics", expected: http.StatusOK},
+		{method: "HEAD", path: "/dashboard/", expected: http.StatusOK},
+		{method: "OPTIONS", path: "/healthz", expected: http.StatusMethodNotAllowed, allow: "GET, HEAD"},
+		{method: "POST", path: "/status/json", expected: http.StatusMethodNotAllowed, allow: "GET, HEAD"},
+		{method: "PUT", path: "/api/v1/exclusions", expected: http.StatusMethodNotAllowed, allow: "GET, HEAD, POST, DELETE"},
+		{method: "HEAD", path: "/api/v1/exclusions", expected: http.StatusNotImplemented},
+		{method: "HEAD", path: "/unknown", expected: http.StatusNotFound},
+	}
+
+	for _, test := range tests {
+		t.Run(test.method+" "+test.path, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			h.ServeHTTP(w, httptest.NewRequest(test.method, test.path, nil))
+
+			if w.Code != test.expected {
+				t.Errorf("ServeHTTP() status = %d, want %d", w.Code, test.expected)
+			}
+
+			if allow := w.Header().Get("Allow"); allow != test.allow {
+				t.Errorf("ServeHTTP() Allow = %q, want %q", allow, test.allow)
+			}
+		})
+	}
+}
@@ -0,0 +1,223 @@
+package http
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/data/safety"
+)
+
+type apiExclusions struct {
+	Excluded   []string `json:"excluded"`
+	InProgress []string `json:"in_progress"`
+}
+
+type apiMaintenanceZone struct {
+	Zone             string  `json:"zone"`
+	RemainingSeconds float64 `json:"remaining_seconds"`
+}
+
+type apiManagementResult struct {
+	Action  string   `json:"action"`
+	Targets []string `json:"targets"`
+	DryRun  bool     `json:"dry_run"`
+	Applied bool     `json:"applied"`
+	Reasons []string `json:"reasons"`
+	Error   string   `json:"error,omitempty"`
+}
+
+func managed(path string) bool {
+	return path == "/api/v1/exclusions" || path == "/api/v1/maintenance"
+}
+
+func allowedMethods(path string) []string {
+	if managed(path) {
+		return []string{"GET", "HEAD", "POST", "DELETE"}
+	}
+
+	return []string{"GET", "HEAD"}
+}
+
+func modifies(r *http.Request) bool {
+	return managed(r.URL.Path) && r.Method != "GET" && r.Method != "HEAD"
+}
+
+func writeJSON(w http.ResponseWriter, code int, body any) {
+	w.Header().Add("content-type", "application/json")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(body)
+}
+
+func dryRun(r *http.Request) (bool, error) {
+	v := r.URL.Query().Get("dry_run")
+	if v == "" {
+		return false, nil
+	}
+
+	return strconv.ParseBool(v)
+}
+
+func (h *HTTP) manageRoot() (fdb.Root, error) {
+	e, _, err := h.cache.get()
+	if err != nil {
+		return fdb.Root{}, err
+	}
+
+	return fdb.Decode(e.data)
+}
+
+func (h *HTTP) apply(w http.ResponseWriter, r *http.Request, result apiManagementResult, check func() ([]string, error), fn func(string) error) {
+	var err error
+	if result.DryRun, err = dryRun(r); err != nil {
+		http.Error(w, fmt.Sprintf("dry_run: %s", err.Error()), http.StatusBadRequest)
+		return
+	}
+
+	if len(result.Targets) == 0 {
+		http.Error(w, "no targets provided", http.StatusBadRequest)
+		return
+	}
+
+	if result.Reasons, err = check(); err != nil {
+		statusError(w, err)
+		return
+	}
+
+	if len(result.Reasons) > 0 {
+		writeJSON(w, http.StatusConflict, result)
+		return
+	}
+
+	result.Reasons = []string{}
+
+	if !result.DryRun {
+		for _, target := range result.Targets {
+			if err := fn(target); err != nil {
+				result.Error = err.Error()
+				writeJSON(w, http.StatusInternalServerError, result)
+				return
+			}
+		}
+		result.Applied = true
+	}
+
+	writeJSON(w, http.StatusOK, result)
+}
+
+func (h *HTTP) serveExclusions(w http.ResponseWriter, r *http.Request) {
+	if h.em == nil {
+		http.Error(w, "status source does not support managing exclusions", http.StatusNotImplemented)
+		return
+	}
+
+	targets := r.URL.Query()["address"]
+
+	switch r.Method {
+	case "GET", "HEAD":
+		excluded, err := h.em.ExcludedProcesses()
+		if err != nil {
+			statusError(w, err)
+			return
+		}
+
+		inProgress, err := h.em.ExclusionInProgressProcesses()
+		if err != nil {
+			statusError(w, err)
+			return
+		}
+
+		if excluded == nil {
+			excluded = []string{}
+		}
+
+		if inProgress == nil {
+			inProgress = []string{}
+		}
+
+		writeJSON(w, http.StatusOK, apiExclusions{Excluded: excluded, InProgress: inProgress})
+	case "POST":
+		h.apply(w, r, apiManagementResult{Action: "exclude", Targets: targets}, func() ([]string, error) {
+			root, err := h.manageRoot()
+			if err != nil {
+				return nil, err
+			}
+
+			return safety.CheckExclude(root, targets), nil
+		}, h.em.ExcludeProcess)
+	case "DELETE":
+		h.apply(w, r, apiManagementResult{Action: "include", Targets: targets}, func() ([]string, error) {
+			excluded, err := h.em.ExcludedProcesses()
+			if err != nil {
+				return nil, err
+			}
+
+			return safety.CheckInclude(excluded, targets), nil
+		}, h.em.IncludeProcess)
+	default:
+		http.NotFound(w, r)
+	}
+}
+
+func (h *HTTP) serveMaintenance(w http.ResponseWriter, r *http.Request) {
+	if h.mm == nil {
+		http.Error(w, "status source does not support managing maintenance zones", http.StatusNotImplemented)
+		return
+	}
+
+	var targets []string
+	if zone := r.URL.Query().Get("zone"); zone != "" {
+		targets = []string{zone}
+	}
+
+	switch r.Method {
+	case "GET", "HEAD":
+		zones, err := h.mm.MaintenanceZones()
+		if err != nil {
+			statusError(w, err)
+			return
+		}
+
+		body := []apiMaintenanceZone{}
+		for _, zone := range sortedKeys(zones) {
+			body = append(body, apiMaintenanceZone{Zone: zone, RemainingSeconds: zones[zone].Seconds()})
+		}
+
+		writeJSON(w, http.StatusOK, body)
+	case "POST":
+		duration, err := time.ParseDuration(r.URL.Query().Get("duration"))
+		if err != nil {
+			http.Error(w, fmt.Sprintf("duration: %s", err.Error()), http.StatusBadRequest)
+			return
+		}
+
+		h.apply(w, r, apiManagementResult{Action: "start_maintenance", Targets: targets}, func() ([]string, error) {
+			root, err := h.manageRoot()
+			if err != nil {
+				return nil, err
+			}
+
+			return safety.CheckMaintenance(root, targets[0], duration), nil
+		}, func(zone string) error {
+			return h.mm.StartMaintenance(zone, duration)
+		})
+	case "DELETE":
+		h.apply(w, r, apiManagementResult{Action: "end_maintenance", Targets: targets}, func() ([]string, error) {
+			zones, err := h.mm.MaintenanceZones()
+			if err != nil {
+				return nil, err
+			}
+
+			if _, found := zones[targets[0]]; !found {
+				return []string{fmt.Sprintf("zone '%s' is not under maintenance", targets[0])}, nil
+			}
+
+			return nil, nil
+		}, h.mm.EndMaintenance)
+	default:
+		http.NotFound(w, r)
+	}
+}
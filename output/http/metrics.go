@@ -0,0 +1,168 @@
+package http
+
+import (
+	"sort"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+func processLabels(p fdb.Process) []label {
+	return []label{
+		{name: "address", value: p.Address},
+		{name: "machine", value: p.Locality[fdb.LocalityMachineID]},
+		{name: "zone", value: p.Locality[fdb.LocalityZoneID]},
+		{name: "dc", value: p.Locality[fdb.LocalityDataCenter]},
+		{name: "class", value: p.Class},
+	}
+}
+
+func withLabels(labels []label, extra ...label) []label {
+	return append(append([]label(nil), labels...), extra...)
+}
+
+func collectMetrics(root fdb.Root) *registry {
+	r := newRegistry()
+	c := root.Cluster
+
+	r.gauge("fdb_cluster_database_available", "Whether the database is available.", boolValue(c.DatabaseAvailable))
+	r.gauge("fdb_cluster_database_locked", "Whether the database is locked.", boolValue(c.DatabaseLockState.Locked))
+	r.gauge("fdb_cluster_data_healthy", "Whether the data distribution state is healthy.", boolValue(c.Data.State.Health))
+	r.gauge("fdb_cluster_data_state", "Current data distribution state, value is always 1.", 1, label{name: "state", value: c.Data.State.Name})
+	r.gauge("fdb_cluster_min_replicas_remaining", "Minimum number of replicas remaining of any data.", float64(c.Data.State.MinReplicasRemaining))
+	r.gauge("fdb_cluster_moving_data_in_flight_bytes", "Bytes of data currently being moved.", float64(c.Data.MovingData.InFlightBytes))
+	r.gauge("fdb_cluster_moving_data_in_queue_bytes", "Bytes of data queued to be moved.", float64(c.Data.MovingData.InQueueBytes))
+	r.gauge("fdb_cluster_messages", "Number of cluster messages reported.", float64(len(c.Messages)))
+
+	r.gauge("fdb_cluster_recovery_state", "Current recovery state, value is always 1.", 1, label{name: "state", value: c.RecoveryState.Name})
+	r.gauge("fdb_cluster_generation", "Current cluster generation, incremented by each recovery.", float64(c.Generation))
+	r.gauge("fdb_cluster_seconds_since_last_recovered", "Seconds since the cluster last finished a recovery.", c.RecoveryState.SecondsSinceLastRecovered)
+
+	r.gauge("fdb_cluster_fault_tolerance_zone_failures", "Zone failures which can be tolerated.", float64(c.FaultTolerance.MaxZoneFailuresWithoutLosingData), label{name: "without_losing", value: "data"})
+	r.gauge("fdb_cluster_fault_tolerance_zone_failures", "Zone failures which can be tolerated.", float64(c.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability), label{name: "without_losing", value: "availability"})
+
+	r.gauge("fdb_cluster_latency_probe_seconds", "Latency of the cluster latency probe.", c.LatencyProbe.TransactionStartSeconds, label{name: "probe", value: "grv"})
+	r.gauge("fdb_cluster_latency_probe_seconds", "Latency of the cluster latency probe.", c.LatencyProbe.ReadSeconds, label{name: "probe", value: "read"})
+	r.gauge("fdb_cluster_latency_probe_seconds", "Latency of the cluster latency probe.", c.LatencyProbe.CommitSeconds, label{name: "probe", value: "commit"})
+
+	r.gauge("fdb_cluster_transactions_per_second_limit", "Ratekeeper transactions per second limit.", c.QoS.TransactionsPerSecondLimit)
+	r.gauge("fdb_cluster_connected_clients", "Number of connected clients.", float64(c.Clients.Count))
+
+	workload := []struct {
+		name  string
+		help  string
+		kind  string
+		stats fdb.Stats
+	}{
+		{"fdb_workload_transactions", "Transactions", "started", c.Workload.Transactions.Started},
+		{"fdb_workload_transactions", "Transactions", "committed", c.Workload.Transactions.Committed},
+		{"fdb_workload_transactions", "Transactions", "conflicted", c.Workload.Transactions.Conflicted},
+		{"fdb_workload_transactions", "Transactions", "rejected_for_queued_too_long", c.Workload.Transactions.RejectedForQueuedTooLong},
+		{"fdb_workload_operations", "Operations", "reads", c.Workload.Operations.Reads},
+		{"fdb_workload_operations", "Operations", "writes", c.Workload.Operations.Writes},
+		{"fdb_workload_bytes", "Bytes", "read", c.Workload.Bytes.Read},
+		{"fdb_workload_bytes", "Bytes", "written", c.Workload.Bytes.Written},
+	}
+
+	for _, wl := range workload {
+		r.gauge(wl.name+"_per_second", wl.help+" per second.", wl.stats.Hz, label{name: "type", value: wl.kind})
+		r.counter(wl.name+"_total", wl.help+" since the cluster started.", wl.stats.Counter, label{name: "type", value: wl.kind})
+	}
+
+	collectProcessMetrics(r, c.Processes)
+	collectBackupMetrics(r, c.Layers)
+
+	return r
+}
+
+func collectProcessMetrics(r *registry, processes map[string]fdb.Process) {
+	addresses := make([]string, 0, len(processes))
+	byAddress := make(map[string]fdb.Process, len(processes))
+
+	for _, p := range processes {
+		addresses = append(addresses, p.Address)
+		byAddress[p.Address] = p
+	}
+
+	sort.Strings(addresses)
+
+	for _, addr := range addresses {
+		p := byAddress[addr]
+		l := processLabels(p)
+
+		r.gauge("fdb_process_cpu_usage_cores", "CPU cores used by the process.", p.CPU.UsageCores, l...)
+		r.gauge("fdb_process_disk_busy_ratio", "Fraction of time the disk was busy.", p.Disk.Busy, l...)
+		r.gauge("fdb_process_disk_free_bytes", "Free bytes on the process data disk.", float64(p.Disk.FreeBytes), l...)
+		r.gauge("fdb_process_disk_total_bytes", "Total bytes on the process data disk.", float64(p.Disk.TotalBytes), l...)
+		r.gauge("fdb_process_disk_reads_per_second", "Disk reads per second.", p.Disk.Reads.Hz, l...)
+		r.gauge("fdb_process_disk_writes_per_second", "Disk writes per second.", p.Disk.Writes.Hz, l...)
+		r.gauge("fdb_process_memory_rss_bytes", "Resident memory of the process.", float64(p.Memory.RSSBytes), l...)
+		r.gauge("fdb_process_memory_used_bytes", "Memory used by the process.", float64(p.Memory.UsedBytes), l...)
+		r.gauge("fdb_process_memory_available_bytes", "Memory available to the process.", float64(p.Memory.AvailableBytes), l...)
+		r.gauge("fdb_process_network_megabits_sent_per_second", "Megabits sent per second.", p.Network.MegabitsSent.Hz, l...)
+		r.gauge("fdb_process_network_megabits_received_per_second", "Megabits received per second.", p.Network.MegabitsReceived.Hz, l...)
+		r.gauge("fdb_process_uptime_seconds", "Seconds since the process started.", p.Uptime, l...)
+		r.gauge("fdb_process_degraded", "Whether the process is degraded.", boolValue(p.Degraded), l...)
+		r.gauge("fdb_process_excluded", "Whether the process is excluded.", boolValue(p.Excluded), l...)
+		r.gauge("fdb_process_under_maintenance", "Whether the process is in a maintenance zone.", boolValue(p.UnderMaintenance), l...)
+		r.gauge("fdb_process_messages", "Number of messages reported by the process.", float64(len(p.Messages)), l...)
+
+		for _, role := range p.Roles {
+			rl := withLabels(l, label{name: "role", value: role.Role}, label{name: "id", value: role.Id})
+
+			r.gauge("fdb_process_role", "Role recruited on the process, value is always 1.", 1, rl...)
+
+			switch role.Role {
+			case "storage":
+				r.gauge("fdb_role_storage_data_lag_seconds", "Storage server data lag.", role.DataLag.Seconds, rl...)
+				r.gauge("fdb_role_storage_durability_lag_seconds", "Storage server durability lag.", role.DurabilityLag.Seconds, rl...)
+				r.gauge("fdb_role_storage_kv_used_bytes", "Bytes used by the storage server key value store.", role.KVUsedBytes, rl...)
+				r.gauge("fdb_role_queue_bytes", "Bytes input but not yet durable.", role.InputBytes.Counter-role.DurableBytes.Counter, rl...)
+			case "log":
+				r.gauge("fdb_role_log_queue_disk_used_bytes", "Bytes used by the log server disk queue.", role.QueueUsedBytes, rl...)
+				r.gauge("fdb_role_queue_bytes", "Bytes input but not yet durable.", role.InputBytes.Counter-role.DurableBytes.Counter, rl...)
+			}
+		}
+	}
+}
+
+func collectBackupMetrics(r *registry, layers fdb.Layers) {
+	for _, id := range sortedKeys(layers.Backup.Tags) {
+		tag := layers.Backup.Tags[id]
+		l := []label{{name: "tag", value: id}}
+
+		r.gauge("fdb_backup_running", "Whether the backup is running.", boolValue(tag.RunningBackup), l...)
+		r.gauge("fdb_backup_restorable", "Whether the running backup is restorable.", boolValue(tag.RunningBackupIsRestorable), l...)
+		r.gauge("fdb_backup_seconds_behind", "Seconds the last restorable point is behind.", tag.LastRestorableSecondsBehind, l...)
+		r.gauge("fdb_backup_state", "Current backup state, value is always 1.", 1, withLabels(l, label{name: "state", value: tag.CurrentStatus})...)
+	}
+
+	for _, dr := range []struct {
+		side   string
+		backup fdb.DRBackup
+	}{
+		{"source", layers.DRBackup},
+		{"destination", layers.DRBackupDest},
+	} {
+		for _, id := range sortedKeys(dr.backup.Tags) {
+			tag := dr.backup.Tags[id]
+			l := []label{{name: "tag", value: id}, {name: "side", value: dr.side}}
+
+			r.gauge("fdb_dr_backup_running", "Whether the DR backup is running.", boolValue(tag.RunningBackup), l...)
+			r.gauge("fdb_dr_backup_restorable", "Whether the DR backup is restorable.", boolValue(tag.BackupRestorable), l...)
+			r.gauge("fdb_dr_backup_seconds_behind", "Seconds the DR destination is behind.", tag.SecondsBehind, l...)
+			r.gauge("fdb_dr_backup_state", "Current DR backup state, value is always 1.", 1, withLabels(l, label{name: "state", value: tag.BackupState})...)
+		}
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
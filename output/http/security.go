@@ -0,0 +1,267 @@
+package http
+
+import (
+	"bufio"
+	"crypto/subtle"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+)
+
+const unixPrefix = "unix:"
+
+type security struct {
+	token  string
+	write  string
+	basic  map[string]string
+	allow  []*net.IPNet
+	public bool
+}
+
+func loadSecurity() (*security, error) {
+	s := &security{}
+
+	if *httpTokenFile != "" {
+		d, err := os.ReadFile(*httpTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("read token file: %w", err)
+		}
+
+		if s.token = strings.TrimSpace(string(d)); s.token == "" {
+			return nil, fmt.Errorf("token file %s is empty", *httpTokenFile)
+		}
+	}
+
+	if *httpWriteTokenFile != "" {
+		d, err := os.ReadFile(*httpWriteTokenFile)
+		if err != nil {
+			return nil, fmt.Errorf("read write token file: %w", err)
+		}
+
+		if s.write = strings.TrimSpace(string(d)); s.write == "" {
+			return nil, fmt.Errorf("write token file %s is empty", *httpWriteTokenFile)
+		}
+	}
+
+	if *httpBasicAuthFile != "" {
+		basic, err := loadBasicAuth(*httpBasicAuthFile)
+		if err != nil {
+			return nil, err
+		}
+		s.basic = basic
+	}
+
+	if *httpAllow != "" {
+		for _, entry := range strings.Split(*httpAllow, ",") {
+			n, err := parseAllow(strings.TrimSpace(entry))
+			if err != nil {
+				return nil, err
+			}
+			s.allow = append(s.allow, n)
+		}
+	}
+
+	s.public = s.token == "" && len(s.basic) == 0
+
+	return s, nil
+}
+
+func loadBasicAuth(fn string) (map[string]string, error) {
+	f, err := os.Open(fn)
+	if err != nil {
+		return nil, fmt.Errorf("open basic auth file: %w", err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+
+	basic := make(map[string]string)
+	scanner := bufio.NewScanner(f)
+
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		user, password, ok := strings.Cut(line, ":")
+		if !ok || user == "" {
+			return nil, fmt.Errorf("basic auth file %s: expected user:password, got %q", fn, line)
+		}
+
+		basic[user] = password
+	}
+
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read basic auth file: %w", err)
+	}
+
+	return basic, nil
+}
+
+func parseAllow(entry string) (*net.IPNet, error) {
+	if !strings.Contains(entry, "/") {
+		ip := net.ParseIP(entry)
+		if ip == nil {
+			return nil, fmt.Errorf("allow list: invalid address %q", entry)
+		}
+
+		bits := 128
+		if ip.To4() != nil {
+			ip = ip.To4()
+			bits = 32
+		}
+
+		return &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)}, nil
+	}
+
+	_, n, err := net.ParseCIDR(entry)
+	if err != nil {
+		return nil, fmt.Errorf("allow list: %w", err)
+	}
+
+	return n, nil
+}
+
+func (s *security) allowed(r *http.Request) bool {
+	if len(s.allow) == 0 {
+		return true
+	}
+
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return false
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil {
+		return false
+	}
+
+	for _, n := range s.allow {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+
+	return false
+}
+
+func (s *security) writable(r *http.Request) bool {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	return ok && s.write != "" && subtle.ConstantTimeCompare([]byte(token), []byte(s.write)) == 1
+}
+
+func (s *security) authenticated(r *http.Request) bool {
+	if s.public || s.writable(r) {
+		return true
+	}
+
+	auth := r.Header.Get("Authorization")
+
+	if token, ok := strings.CutPrefix(auth, "Bearer "); ok && s.token != "" {
+		return subtle.ConstantTimeCompare([]byte(token), []byte(s.token)) == 1
+	}
+
+	if user, password, ok := r.BasicAuth(); ok && s.basic != nil {
+		expected, found := s.basic[user]
+		return found && subtle.ConstantTimeCompare([]byte(password), []byte(expected)) == 1
+	}
+
+	return false
+}
+
+func probe(r *http.Request) bool {
+	return r.URL.Path == "/healthz" || r.URL.Path == "/readyz"
+}
+
+func (s *security) wrap(next http.Handler, unix bool) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !unix && !s.allowed(r) {
+			http.Error(w, "forbidden", http.StatusForbidden)
+			return
+		}
+
+		if modifies(r) {
+			if s.write == "" {
+				http.Error(w, "management is disabled, no write token is configured", http.StatusForbidden)
+				return
+			}
+
+			if !s.writable(r) {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="fdbexplorer"`)
+				http.Error(w, "unauthorized", http.StatusUnauthorized)
+				return
+			}
+		}
+
+		if !s.authenticated(r) && !probe(r) {
+			if s.basic != nil {
+				w.Header().Set("WWW-Authenticate", `Basic realm="fdbexplorer"`)
+			} else {
+				w.Header().Set("WWW-Authenticate", `Bearer realm="fdbexplorer"`)
+			}
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+func loadTLS() (*tls.Config, error) {
+	if *httpTLSCert == "" && *httpTLSKey == "" {
+		if *httpTLSClientCA != "" {
+			return nil, fmt.Errorf("-http-tls-client-ca requires -http-tls-cert and -http-tls-key")
+		}
+		return nil, nil
+	}
+
+	cert, err := tls.LoadX509KeyPair(*httpTLSCert, *httpTLSKey)
+	if err != nil {
+		return nil, fmt.Errorf("load tls key pair: %w", err)
+	}
+
+	config := &tls.Config{
+		MinVersion:   tls.VersionTLS12,
+		Certificates: []tls.Certificate{cert},
+	}
+
+	if *httpTLSClientCA != "" {
+		d, err := os.ReadFile(*httpTLSClientCA)
+		if err != nil {
+			return nil, fmt.Errorf("read client ca: %w", err)
+		}
+
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(d) {
+			return nil, fmt.Errorf("client ca %s contains no certificates", *httpTLSClientCA)
+		}
+
+		config.ClientCAs = pool
+		config.ClientAuth = tls.RequireAndVerifyClientCert
+	}
+
+	return config, nil
+}
+
+func listen(address string) (net.Listener, bool, error) {
+	path, unix := strings.CutPrefix(address, unixPrefix)
+	if !unix {
+		ln, err := net.Listen("tcp", address)
+		return ln, false, err
+	}
+
+	if fi, err := os.Stat(path); err == nil && fi.Mode()&os.ModeSocket != 0 {
+		if err := os.Remove(path); err != nil {
+			return nil, true, fmt.Errorf("remove stale socket: %w", err)
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	return ln, true, err
+}
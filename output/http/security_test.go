@@ -0,0 +1,164 @@
+package http
+
+import (
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestParseAllow(t *testing.T) {
+	tests := []struct {
+		entry    string
+		expected string
+		err      bool
+	}{
+		{entry: "10.0.0.1", expected: "10.0.0.1/32"},
+		{entry: "10.0.0.0/8", expected: "10.0.0.0/8"},
+		{entry: "10.1.2.3/16", expected: "10.1.0.0/16"},
+		{entry: "::1", expected: "::1/128"},
+		{entry: "fd00::/8", expected: "fd00::/8"},
+		{entry: "10.0.0", err: true},
+		{entry: "10.0.0.0/33", err: true},
+		{entry: "", err: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.entry, func(t *testing.T) {
+			n, err := parseAllow(test.entry)
+			if test.err {
+				if err == nil {
+					t.Errorf("parseAllow(%q) = %s, want error", test.entry, n)
+				}
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("parseAllow(%q) error: %v", test.entry, err)
+			}
+
+			if n.String() != test.expected {
+				t.Errorf("parseAllow(%q) = %s, want %s", test.entry, n, test.expected)
+			}
+		})
+	}
+}
+
+func TestAuthenticated(t *testing.T) {
+	tests := []struct {
+		name     string
+		security security
+		bearer   string
+		user     string
+		password string
+		expected bool
+	}{
+		{name: "public", security: security{public: true}, expected: true},
+		{name: "no credentials", security: security{token: "read"}},
+		{name: "read token", security: security{token: "read"}, bearer: "read", expected: true},
+		{name: "wrong token", security: security{token: "read"}, bearer: "wrong"},
+		{name: "write token", security: security{token: "read", write: "write"}, bearer: "write", expected: true},
+		{name: "empty write token", security: security{token: "read"}, bearer: ""},
+		{name: "basic auth", security: security{basic: map[string]string{"user": "pass"}}, user: "user", password: "pass", expected: true},
+		{name: "basic auth wrong password", security: security{basic: map[string]string{"user": "pass"}}, user: "user", password: "wrong"},
+		{name: "basic auth unknown user", security: security{basic: map[string]string{"user": "pass"}}, user: "other", password: "pass"},
+		{name: "basic auth not configured", security: security{token: "read"}, user: "user", password: "pass"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/status/json", nil)
+			if test.bearer != "" {
+				r.Header.Set("Authorization", "Bearer "+test.bearer)
+			}
+			if test.user != "" {
+				r.SetBasicAuth(test.user, test.password)
+			}
+
+			if actual := test.security.authenticated(r); actual != test.expected {
+				t.Errorf("authenticated() = %t, want %t", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestWrap(t *testing.T) {
+	_, local, _ := net.ParseCIDR("127.0.0.0/8")
+
+	tests := []struct {
+		name     string
+		security security
+		unix     bool
+		remote   string
+		bearer   string
+		expected int
+	}{
+		{name: "public", security: security{public: true}, expected: http.StatusOK},
+		{name: "token", security: security{token: "read"}, bearer: "read", expected: http.StatusOK},
+		{name: "missing token", security: security{token: "read"}, expected: http.StatusUnauthorized},
+		{name: "allowed address", security: security{public: true, allow: []*net.IPNet{local}}, remote: "127.0.0.1:5000", expected: http.StatusOK},
+		{name: "disallowed address", security: security{public: true, allow: []*net.IPNet{local}}, remote: "10.0.0.1:5000", expected: http.StatusForbidden},
+		{name: "unix socket ignores allow list", security: security{public: true, allow: []*net.IPNet{local}}, unix: true, remote: "10.0.0.1:5000", expected: http.StatusOK},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/status/json", nil)
+			if test.remote != "" {
+				r.RemoteAddr = test.remote
+			}
+			if test.bearer != "" {
+				r.Header.Set("Authorization", "Bearer "+test.bearer)
+			}
+
+			w := httptest.NewRecorder()
+			test.security.wrap(next, test.unix).ServeHTTP(w, r)
+
+			if w.Code != test.expected {
+				t.Errorf("wrap() status = %d, want %d", w.Code, test.expected)
+			}
+		})
+	}
+}
+
+func TestWrapManagement(t *testing.T) {
+	tests := []struct {
+		name     string
+		security security
+		method   string
+		path     string
+		bearer   string
+		expected int
+	}{
+		{name: "read with write token configured", security: security{token: "read", write: "write"}, method: "GET", path: "/api/v1/exclusions", bearer: "read", expected: http.StatusOK},
+		{name: "head with read token", security: security{token: "read", write: "write"}, method: "HEAD", path: "/api/v1/exclusions", bearer: "read", expected: http.StatusOK},
+		{name: "management disabled", security: security{public: true}, method: "POST", path: "/api/v1/exclusions", expected: http.StatusForbidden},
+		{name: "missing write token", security: security{public: true, write: "write"}, method: "POST", path: "/api/v1/exclusions", expected: http.StatusUnauthorized},
+		{name: "read token cannot write", security: security{token: "read", write: "write"}, method: "POST", path: "/api/v1/maintenance", bearer: "read", expected: http.StatusUnauthorized},
+		{name: "write token", security: security{token: "read", write: "write"}, method: "DELETE", path: "/api/v1/maintenance", bearer: "write", expected: http.StatusOK},
+	}
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := httptest.NewRequest(test.method, test.path, nil)
+			if test.bearer != "" {
+				r.Header.Set("Authorization", "Bearer "+test.bearer)
+			}
+
+			w := httptest.NewRecorder()
+			test.security.wrap(next, true).ServeHTTP(w, r)
+
+			if w.Code != test.expected {
+				t.Errorf("wrap() status = %d, want %d", w.Code, test.expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package http
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"time"
+)
+
+const (
+	streamModeSnapshot = "snapshot"
+	streamModeDiff     = "diff"
+)
+
+const streamKeepAlive = 15 * time.Second
+
+func serveStream(w http.ResponseWriter, r *http.Request, c *cache) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	mode := r.URL.Query().Get("mode")
+	switch mode {
+	case "":
+		mode = streamModeSnapshot
+	case streamModeSnapshot, streamModeDiff:
+	default:
+		http.Error(w, fmt.Sprintf("unknown mode %q", mode), http.StatusBadRequest)
+		return
+	}
+
+	updates, cancel := c.subscribe()
+	defer cancel()
+
+	w.Header().Set("content-type", "text/event-stream")
+	w.Header().Set("cache-control", "no-cache")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	if r.Method == "HEAD" {
+		return
+	}
+
+	s := &stream{w: w, mode: mode}
+
+	if e, _, err := c.get(); err == nil {
+		if err := s.send(e); err != nil {
+			return
+		}
+		flusher.Flush()
+	}
+
+	keepAlive := time.NewTicker(streamKeepAlive)
+	defer keepAlive.Stop()
+
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case e := <-updates:
+			if err := s.send(e); err != nil {
+				return
+			}
+		case <-keepAlive.C:
+			if _, err := fmt.Fprint(w, ": keepalive\n\n"); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+	}
+}
+
+type stream struct {
+	w    http.ResponseWriter
+	mode string
+	seq  int
+	etag string
+	prev any
+}
+
+func (s *stream) send(e *entry) error {
+	if e.etag == s.etag {
+		return nil
+	}
+
+	event := streamModeSnapshot
+	var payload []byte
+
+	if s.mode == streamModeDiff {
+		next, err := decodeGeneric(e.data)
+		if err != nil {
+			return err
+		}
+
+		if s.prev == nil {
+			payload, err = json.Marshal(next)
+		} else {
+			event = streamModeDiff
+			patch, _ := mergePatch(s.prev, next)
+			payload, err = json.Marshal(patch)
+		}
+
+		if err != nil {
+			return err
+		}
+
+		s.prev = next
+	} else {
+		var buf bytes.Buffer
+		if err := json.Compact(&buf, e.data); err != nil {
+			return err
+		}
+		payload = buf.Bytes()
+	}
+
+	s.seq++
+	s.etag = e.etag
+
+	_, err := fmt.Fprintf(s.w, "id: %d\nevent: %s\ndata: %s\n\n", s.seq, event, payload)
+	return err
+}
+
+func decodeGeneric(d []byte) (any, error) {
+	dec := json.NewDecoder(bytes.NewReader(d))
+	dec.UseNumber()
+
+	var v any
+	if err := dec.Decode(&v); err != nil {
+		return nil, err
+	}
+
+	return v, nil
+}
+
+func mergePatch(prev, next any) (any, bool) {
+	prevObj, prevIsObj := prev.(map[string]any)
+	nextObj, nextIsObj := next.(map[string]any)
+
+	if !prevIsObj || !nextIsObj {
+		return next, !reflect.DeepEqual(prev, next)
+	}
+
+	patch := make(map[string]any)
+
+	for k, nv := range nextObj {
+		pv, found := prevObj[k]
+		if !found {
+			patch[k] = nv
+			continue
+		}
+
+		if p, changed := mergePatch(pv, nv); changed {
+			patch[k] = p
+		}
+	}
+
+	for k := range prevObj {
+		if _, found := nextObj[k]; !found {
+			patch[k] = nil
+		}
+	}
+
+	return patch, len(patch) > 0
+}
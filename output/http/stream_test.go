@@ -0,0 +1,83 @@
+package http
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+)
+
+func decode(t *testing.T, s string) any {
+	var v any
+	if err := json.Unmarshal([]byte(s), &v); err != nil {
+		t.Fatalf("unmarshal %s: %v", s, err)
+	}
+
+	return v
+}
+
+func TestMergePatch(t *testing.T) {
+	tests := []struct {
+		name    string
+		prev    string
+		next    string
+		patch   string
+		changed bool
+	}{
+		{
+			name:  "unchanged",
+			prev:  `{"a":1,"b":{"c":"x"}}`,
+			next:  `{"a":1,"b":{"c":"x"}}`,
+			patch: `{}`,
+		},
+		{
+			name:    "changed scalar",
+			prev:    `{"a":1,"b":2}`,
+			next:    `{"a":1,"b":3}`,
+			patch:   `{"b":3}`,
+			changed: true,
+		},
+		{
+			name:    "added and removed keys",
+			prev:    `{"a":1,"b":2}`,
+			next:    `{"a":1,"c":3}`,
+			patch:   `{"b":null,"c":3}`,
+			changed: true,
+		},
+		{
+			name:    "nested object",
+			prev:    `{"a":{"b":{"c":1,"d":2}}}`,
+			next:    `{"a":{"b":{"c":1,"d":5}}}`,
+			patch:   `{"a":{"b":{"d":5}}}`,
+			changed: true,
+		},
+		{
+			name:    "arrays are replaced whole",
+			prev:    `{"a":[1,2,3]}`,
+			next:    `{"a":[1,2,4]}`,
+			patch:   `{"a":[1,2,4]}`,
+			changed: true,
+		},
+		{
+			name:    "object replaced by scalar",
+			prev:    `{"a":{"b":1}}`,
+			next:    `{"a":2}`,
+			patch:   `{"a":2}`,
+			changed: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			prev, next, expected := decode(t, test.prev), decode(t, test.next), decode(t, test.patch)
+
+			patch, changed := mergePatch(prev, next)
+			if changed != test.changed {
+				t.Errorf("mergePatch() changed = %t, want %t", changed, test.changed)
+			}
+
+			if !reflect.DeepEqual(patch, expected) {
+				t.Errorf("mergePatch() = %v, want %v", patch, expected)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package report
+
+import (
+	"flag"
+	"fmt"
+	"os"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/data/upgrade"
+	"github.com/pwood/fdbexplorer/input"
+)
+
+var upgradeReport *bool
+
+func init() {
+	upgradeReport = flag.Bool("upgrade-report", false, "Print an upgrade readiness report for -target-version and exit, non-zero if not ready.")
+}
+
+func NewReport(ds input.StatusProvider) (*Report, bool) {
+	if !*upgradeReport {
+		return nil, false
+	}
+
+	return &Report{ds: ds}, true
+}
+
+type Report struct {
+	ds input.StatusProvider
+}
+
+func (r *Report) Run() {
+	target := upgrade.TargetVersion()
+	if target == "" {
+		fmt.Fprintln(os.Stderr, "An upgrade report requires -target-version.")
+		os.Exit(2)
+	}
+
+	if _, ok := upgrade.VersionMajorMinor(target); !ok {
+		fmt.Fprintf(os.Stderr, "Target version '%s' is not a major.minor version.\n", target)
+		os.Exit(2)
+	}
+
+	d, err := r.ds.Status()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to query data source: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	root, err := fdb.Decode(d)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to unmarshal data: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	report := upgrade.NewReport(root, target)
+
+	if err := report.WriteText(os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "Failed to write report: %s\n", err.Error())
+		os.Exit(2)
+	}
+
+	if !report.Ready() {
+		os.Exit(1)
+	}
+}
@@ -3,6 +3,7 @@ package output
 import (
 	"github.com/pwood/fdbexplorer/input"
 	"github.com/pwood/fdbexplorer/output/http"
+	"github.com/pwood/fdbexplorer/output/report"
 	"github.com/pwood/fdbexplorer/output/ui"
 )
 
@@ -11,9 +12,28 @@ type Output interface {
 }
 
 func Select(ds input.StatusProvider) Output {
-	if out, ok := http.NewHTTP(ds); ok {
+	if ds == nil {
+		if out, ok := http.NewHTTP(nil); ok {
+			return out
+		}
+
+		return nil
+	}
+
+	if out, ok := report.NewReport(ds); ok {
 		return out
 	}
 
+	if out, ok := http.NewHTTP(ds); ok {
+		if !http.WithUI() {
+			return out
+		}
+
+		main := ui.New(ds)
+		main.AddPublisher(out)
+
+		return &Combined{http: out, ui: main}
+	}
+
 	return ui.New(ds)
 }
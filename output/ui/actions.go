@@ -43,8 +43,18 @@ func (m *Main) rootAction(event *tcell.EventKey) *tcell.EventKey {
 		}
 	case tcell.KeyF3:
 		m.interval.Next()
+	case tcell.KeyF4:
+		if filename, err := m.exportEvents(); err != nil {
+			m.updateStatus(fmt.Sprintf("Failed to export events: %s", err.Error()), StatusFailure)
+		} else {
+			m.updateStatus(fmt.Sprintf("Events exported: %s", filename), StatusSuccess)
+		}
 	case tcell.KeyF5:
 		m.upCh <- struct{}{}
+	case tcell.KeyF6:
+		if m.cs != nil {
+			m.switchCh <- struct{}{}
+		}
 	case tcell.KeyF7:
 		if m.em != nil {
 			if err := manageProcesses(m.em, m.processStore, true); err != nil {
@@ -58,7 +68,11 @@ func (m *Main) rootAction(event *tcell.EventKey) *tcell.EventKey {
 			}
 		}
 	case tcell.KeyESC:
-		m.app.Stop()
+		if m.detailOpen() {
+			m.closeDetail()
+		} else {
+			m.app.Stop()
+		}
 	case tcell.KeyCtrlL:
 		go m.app.Draw()
 	case tcell.KeyRune:
@@ -0,0 +1,107 @@
+package components
+
+import (
+	"sync"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/rivo/tview"
+)
+
+type ChartSeries struct {
+	Name   string
+	Color  tcell.Color
+	Values []float64
+}
+
+func NewChart(title string, format func(float64) string) *Chart {
+	c := &Chart{
+		Box:    tview.NewBox(),
+		format: format,
+
+		m: &sync.RWMutex{},
+	}
+
+	c.SetBorder(true)
+	c.SetTitle(title)
+	c.SetTitleColor(tcell.ColorAqua)
+
+	return c
+}
+
+type Chart struct {
+	*tview.Box
+
+	format func(float64) string
+
+	m      *sync.RWMutex
+	series []ChartSeries
+}
+
+func (c *Chart) Update(series []ChartSeries) {
+	c.m.Lock()
+	c.series = series
+	c.m.Unlock()
+}
+
+func (c *Chart) Draw(screen tcell.Screen) {
+	c.DrawForSubclass(screen, c)
+
+	c.m.RLock()
+	defer c.m.RUnlock()
+
+	x, y, width, height := c.GetInnerRect()
+	if width < 2 || height < 2 {
+		return
+	}
+
+	maxValue := 0.0
+	for _, s := range c.series {
+		for _, v := range s.Values {
+			if v > maxValue {
+				maxValue = v
+			}
+		}
+	}
+
+	maxLabel := c.format(maxValue)
+	minLabel := c.format(0)
+
+	axisWidth := len(maxLabel)
+	if len(minLabel) > axisWidth {
+		axisWidth = len(minLabel)
+	}
+
+	tview.Print(screen, maxLabel, x, y, axisWidth, tview.AlignRight, tcell.ColorGray)
+	tview.Print(screen, minLabel, x, y+height-1, axisWidth, tview.AlignRight, tcell.ColorGray)
+
+	plotX := x + axisWidth + 1
+	plotWidth := width - axisWidth - 1
+
+	if plotWidth < 1 {
+		return
+	}
+
+	for row := 0; row < height; row++ {
+		screen.SetContent(plotX-1, y+row, tview.BoxDrawingsLightVertical, nil, tcell.StyleDefault.Foreground(tcell.ColorGray))
+	}
+
+	for _, s := range c.series {
+		values := s.Values
+		if len(values) > plotWidth {
+			values = values[len(values)-plotWidth:]
+		}
+
+		offset := plotWidth - len(values)
+		style := tcell.StyleDefault.Foreground(s.Color)
+
+		for i, v := range values {
+			row := height - 1
+
+			if maxValue > 0 {
+				row = height - 1 - int(v/maxValue*float64(height-1)+0.5)
+			}
+
+			screen.SetContent(plotX+offset+i, y+row, '•', nil, style)
+		}
+	}
+}
@@ -0,0 +1,35 @@
+package components
+
+const sparkTicks = "▁▂▃▄▅▆▇█"
+
+func Sparkline(values []float64, width int) string {
+	if len(values) > width {
+		values = values[len(values)-width:]
+	}
+
+	if len(values) == 0 {
+		return ""
+	}
+
+	maxValue := 0.0
+	for _, v := range values {
+		if v > maxValue {
+			maxValue = v
+		}
+	}
+
+	ticks := []rune(sparkTicks)
+	line := make([]rune, len(values))
+
+	for i, v := range values {
+		idx := 0
+
+		if maxValue > 0 && v > 0 {
+			idx = int(v / maxValue * float64(len(ticks)-1))
+		}
+
+		line[i] = ticks[idx]
+	}
+
+	return string(line)
+}
@@ -0,0 +1,155 @@
+package alerts
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+const (
+	ScopeProcess = "process"
+	ScopeCluster = "cluster"
+)
+
+type Alert struct {
+	Rule    Rule
+	Subject string
+	Value   float64
+	Since   time.Time
+}
+
+type key struct {
+	rule    int
+	subject string
+}
+
+type Engine struct {
+	m       *sync.RWMutex
+	rules   []Rule
+	pending map[key]time.Time
+	firing  map[key]Alert
+}
+
+func NewEngine(rules []Rule) *Engine {
+	return &Engine{
+		m:       &sync.RWMutex{},
+		rules:   rules,
+		pending: make(map[key]time.Time),
+		firing:  make(map[key]Alert),
+	}
+}
+
+func (e *Engine) Reset() {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	e.pending = make(map[key]time.Time)
+	e.firing = make(map[key]Alert)
+}
+
+func (e *Engine) Rules() []Rule {
+	return e.rules
+}
+
+func (e *Engine) Evaluate(root fdb.Root, now time.Time) []Alert {
+	e.m.Lock()
+	defer e.m.Unlock()
+
+	pending := make(map[key]time.Time)
+	firing := make(map[key]Alert)
+	var started []Alert
+
+	observe := func(i int, subject string, v float64) {
+		rule := e.rules[i]
+		if !rule.matches(v) {
+			return
+		}
+
+		k := key{rule: i, subject: subject}
+
+		since, ok := e.pending[k]
+		if !ok {
+			since = now
+		}
+		pending[k] = since
+
+		if now.Sub(since) < time.Duration(rule.For) {
+			return
+		}
+
+		alert := Alert{Rule: rule, Subject: subject, Value: v, Since: since}
+		firing[k] = alert
+
+		if _, already := e.firing[k]; !already {
+			started = append(started, alert)
+		}
+	}
+
+	for i, rule := range e.rules {
+		if fn, ok := clusterMetrics[rule.Metric]; ok {
+			if v, ok := fn(root.Cluster); ok {
+				observe(i, process.ClusterSubject, v)
+			}
+			continue
+		}
+
+		fn := processMetrics[rule.Metric]
+		for _, p := range root.Cluster.Processes {
+			if v, ok := fn(p); ok {
+				observe(i, p.Address, v)
+			}
+		}
+	}
+
+	e.pending = pending
+	e.firing = firing
+
+	return started
+}
+
+func (e *Engine) Firing() []Alert {
+	e.m.RLock()
+	defer e.m.RUnlock()
+
+	alerts := make([]Alert, 0, len(e.firing))
+	for _, a := range e.firing {
+		alerts = append(alerts, a)
+	}
+
+	sort.Slice(alerts, func(i, j int) bool {
+		if alerts[i].Rule.Severity != alerts[j].Rule.Severity {
+			return alerts[i].Rule.Severity == SeverityCritical
+		}
+
+		if alerts[i].Rule.Name != alerts[j].Rule.Name {
+			return alerts[i].Rule.Name < alerts[j].Rule.Name
+		}
+
+		return alerts[i].Subject < alerts[j].Subject
+	})
+
+	return alerts
+}
+
+func (e *Engine) ProcessHealth() map[string]process.Health {
+	e.m.RLock()
+	defer e.m.RUnlock()
+
+	health := make(map[string]process.Health)
+
+	for k, a := range e.firing {
+		if k.subject == process.ClusterSubject {
+			continue
+		}
+
+		h := a.Rule.Severity.Health()
+		if existing, ok := health[k.subject]; !ok || h < existing {
+			health[k.subject] = h
+		}
+	}
+
+	return health
+}
@@ -0,0 +1,106 @@
+package alerts
+
+import (
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+type processMetric func(fdb.Process) (float64, bool)
+type clusterMetric func(fdb.Cluster) (float64, bool)
+
+var processMetrics = map[string]processMetric{
+	"cpu_percent": func(p fdb.Process) (float64, bool) {
+		return p.CPU.UsageCores * 100, true
+	},
+	"disk_busy_percent": func(p fdb.Process) (float64, bool) {
+		return p.Disk.Busy * 100, true
+	},
+	"disk_free_percent": func(p fdb.Process) (float64, bool) {
+		if p.Disk.TotalBytes == 0 {
+			return 0, false
+		}
+		return float64(p.Disk.FreeBytes) / float64(p.Disk.TotalBytes) * 100, true
+	},
+	"memory_used_percent": func(p fdb.Process) (float64, bool) {
+		if p.Memory.AvailableBytes == 0 {
+			return 0, false
+		}
+		return float64(p.Memory.RSSBytes) / float64(p.Memory.AvailableBytes) * 100, true
+	},
+	"storage_data_lag_seconds": roleMetric("storage", func(r fdb.Role) float64 {
+		return r.DataLag.Seconds
+	}),
+	"storage_durability_lag_seconds": roleMetric("storage", func(r fdb.Role) float64 {
+		return r.DurabilityLag.Seconds
+	}),
+	"storage_kv_used_bytes": roleMetric("storage", func(r fdb.Role) float64 {
+		return r.KVUsedBytes
+	}),
+	"log_queue_bytes": roleMetric("log", func(r fdb.Role) float64 {
+		return r.InputBytes.Counter - r.DurableBytes.Counter
+	}),
+}
+
+var clusterMetrics = map[string]clusterMetric{
+	"database_available": func(c fdb.Cluster) (float64, bool) {
+		if c.DatabaseAvailable {
+			return 1, true
+		}
+		return 0, true
+	},
+	"min_replicas_remaining": func(c fdb.Cluster) (float64, bool) {
+		return float64(c.Data.State.MinReplicasRemaining), true
+	},
+	"moving_data_in_queue_bytes": func(c fdb.Cluster) (float64, bool) {
+		return float64(c.Data.MovingData.InQueueBytes), true
+	},
+	"moving_data_in_flight_bytes": func(c fdb.Cluster) (float64, bool) {
+		return float64(c.Data.MovingData.InFlightBytes), true
+	},
+	"latency_probe_grv_seconds": func(c fdb.Cluster) (float64, bool) {
+		return c.LatencyProbe.TransactionStartSeconds, true
+	},
+	"latency_probe_read_seconds": func(c fdb.Cluster) (float64, bool) {
+		return c.LatencyProbe.ReadSeconds, true
+	},
+	"latency_probe_commit_seconds": func(c fdb.Cluster) (float64, bool) {
+		return c.LatencyProbe.CommitSeconds, true
+	},
+	"zone_failures_without_losing_data": func(c fdb.Cluster) (float64, bool) {
+		return float64(c.FaultTolerance.MaxZoneFailuresWithoutLosingData), true
+	},
+	"zone_failures_without_losing_availability": func(c fdb.Cluster) (float64, bool) {
+		return float64(c.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability), true
+	},
+}
+
+func roleMetric(role string, fn func(fdb.Role) float64) processMetric {
+	return func(p fdb.Process) (float64, bool) {
+		found := false
+		highest := 0.0
+
+		for _, r := range p.Roles {
+			if r.Role != role {
+				continue
+			}
+
+			if v := fn(r); !found || v > highest {
+				highest = v
+			}
+			found = true
+		}
+
+		return highest, found
+	}
+}
+
+func lookupMetric(name string) (string, bool) {
+	if _, ok := processMetrics[name]; ok {
+		return ScopeProcess, true
+	}
+
+	if _, ok := clusterMetrics[name]; ok {
+		return ScopeCluster, true
+	}
+
+	return "", false
+}
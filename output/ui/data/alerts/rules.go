@@ -0,0 +1,181 @@
+package alerts
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+type Severity string
+
+const (
+	SeverityWarning  Severity = "warning"
+	SeverityCritical Severity = "critical"
+)
+
+func (s Severity) Health() process.Health {
+	if s == SeverityCritical {
+		return process.HealthCritical
+	}
+	return process.HealthWarning
+}
+
+type Rule struct {
+	Name     string    `json:"name"`
+	Metric   string    `json:"metric"`
+	Operator string    `json:"operator"`
+	Value    Threshold `json:"value"`
+	For      Duration  `json:"for"`
+	Severity Severity  `json:"severity"`
+}
+
+func (r Rule) matches(v float64) bool {
+	t := float64(r.Value)
+
+	switch r.Operator {
+	case ">":
+		return v > t
+	case ">=":
+		return v >= t
+	case "<":
+		return v < t
+	case "<=":
+		return v <= t
+	case "==":
+		return v == t
+	case "!=":
+		return v != t
+	default:
+		return false
+	}
+}
+
+func (r Rule) validate() error {
+	if r.Name == "" {
+		return fmt.Errorf("rule for %s: name is required", r.Metric)
+	}
+
+	if _, found := lookupMetric(r.Metric); !found {
+		return fmt.Errorf("rule %s: unknown metric %q", r.Name, r.Metric)
+	}
+
+	switch r.Operator {
+	case ">", ">=", "<", "<=", "==", "!=":
+	default:
+		return fmt.Errorf("rule %s: unknown operator %q", r.Name, r.Operator)
+	}
+
+	switch r.Severity {
+	case SeverityWarning, SeverityCritical:
+	default:
+		return fmt.Errorf("rule %s: unknown severity %q", r.Name, r.Severity)
+	}
+
+	return nil
+}
+
+func LoadRules(path string) ([]Rule, error) {
+	d, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read: %w", err)
+	}
+
+	var rules []Rule
+	if err := json.Unmarshal(d, &rules); err != nil {
+		return nil, fmt.Errorf("parse: %w", err)
+	}
+
+	for i := range rules {
+		if rules[i].Severity == "" {
+			rules[i].Severity = SeverityWarning
+		}
+
+		if err := rules[i].validate(); err != nil {
+			return nil, err
+		}
+	}
+
+	return rules, nil
+}
+
+type Threshold float64
+
+var byteSuffixes = []struct {
+	suffix     string
+	multiplier float64
+}{
+	{"TiB", 1 << 40},
+	{"GiB", 1 << 30},
+	{"MiB", 1 << 20},
+	{"KiB", 1 << 10},
+}
+
+func (t *Threshold) UnmarshalJSON(b []byte) error {
+	var f float64
+	if err := json.Unmarshal(b, &f); err == nil {
+		*t = Threshold(f)
+		return nil
+	}
+
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("threshold must be a number or string: %w", err)
+	}
+
+	s = strings.TrimSpace(s)
+
+	for _, bs := range byteSuffixes {
+		if strings.HasSuffix(s, bs.suffix) {
+			f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, bs.suffix)), 64)
+			if err != nil {
+				return fmt.Errorf("threshold %q: %w", s, err)
+			}
+			*t = Threshold(f * bs.multiplier)
+			return nil
+		}
+	}
+
+	if strings.HasSuffix(s, "%") {
+		f, err := strconv.ParseFloat(strings.TrimSpace(strings.TrimSuffix(s, "%")), 64)
+		if err != nil {
+			return fmt.Errorf("threshold %q: %w", s, err)
+		}
+		*t = Threshold(f)
+		return nil
+	}
+
+	if d, err := time.ParseDuration(s); err == nil {
+		*t = Threshold(d.Seconds())
+		return nil
+	}
+
+	f, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return fmt.Errorf("threshold %q: %w", s, err)
+	}
+
+	*t = Threshold(f)
+	return nil
+}
+
+type Duration time.Duration
+
+func (d *Duration) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return fmt.Errorf("duration must be a string such as \"60s\": %w", err)
+	}
+
+	pd, err := time.ParseDuration(s)
+	if err != nil {
+		return fmt.Errorf("duration %q: %w", s, err)
+	}
+
+	*d = Duration(pd)
+	return nil
+}
@@ -0,0 +1,178 @@
+package events
+
+import (
+	"fmt"
+	"slices"
+	"sort"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+func Diff(prev, next process.Update, now time.Time) []Event {
+	var detected []Event
+
+	add := func(kind, subject, format string, args ...any) {
+		detected = append(detected, Event{Time: now, Kind: kind, Subject: subject, Description: fmt.Sprintf(format, args...)})
+	}
+
+	pc, nc := prev.Root.Cluster, next.Root.Cluster
+
+	if pc.RecoveryState.Name != nc.RecoveryState.Name {
+		add(KindRecoveryState, process.ClusterSubject, "Recovery state changed from %s to %s.", pc.RecoveryState.Name, nc.RecoveryState.Name)
+	}
+
+	if pc.DatabaseAvailable != nc.DatabaseAvailable {
+		if nc.DatabaseAvailable {
+			add(KindAvailability, process.ClusterSubject, "Database became available.")
+		} else {
+			add(KindAvailability, process.ClusterSubject, "Database became unavailable.")
+		}
+	}
+
+	if pc.Data.State.Name != nc.Data.State.Name || pc.Data.State.Health != nc.Data.State.Health {
+		add(KindDataState, process.ClusterSubject, "Data state changed from %s to %s.", dataState(pc.Data.State), dataState(nc.Data.State))
+	}
+
+	prevProcesses := processesByAddress(pc.Processes)
+	nextProcesses := processesByAddress(nc.Processes)
+
+	for _, addr := range sortedKeys(prevProcesses) {
+		if _, ok := nextProcesses[addr]; !ok {
+			add(KindProcessRemoved, addr, "Process %s (%s) is no longer reported.", addr, prevProcesses[addr].Class)
+		}
+	}
+
+	for _, addr := range sortedKeys(nextProcesses) {
+		np := nextProcesses[addr]
+
+		pp, ok := prevProcesses[addr]
+		if !ok {
+			add(KindProcessAdded, addr, "Process %s (%s) joined the cluster.", addr, np.Class)
+			continue
+		}
+
+		if np.Uptime < pp.Uptime {
+			add(KindProcessRestarted, addr, "Process %s restarted, uptime went from %.0fs to %.0fs.", addr, pp.Uptime, np.Uptime)
+		}
+
+		if ph, nh := health(pp), health(np); ph != nh {
+			add(KindProcessHealth, addr, "Process %s health changed from %s to %s.", addr, ph, nh)
+		}
+
+		prevRoles, nextRoles := roleNames(pp), roleNames(np)
+
+		for _, role := range nextRoles {
+			if !slices.Contains(prevRoles, role) {
+				add(KindRoleRecruited, addr, "Role %s recruited on %s.", role, addr)
+			}
+		}
+
+		for _, role := range prevRoles {
+			if !slices.Contains(nextRoles, role) {
+				add(KindRoleRemoved, addr, "Role %s no longer on %s.", role, addr)
+			}
+		}
+
+		if !pp.Excluded && np.Excluded {
+			add(KindExcluded, addr, "Process %s is now excluded.", addr)
+		} else if pp.Excluded && !np.Excluded {
+			add(KindIncluded, addr, "Process %s is no longer excluded.", addr)
+		}
+	}
+
+	for _, addr := range prev.ExclusionInProgress {
+		if !slices.Contains(next.ExclusionInProgress, addr) && slices.Contains(next.ExcludedProcesses, addr) {
+			add(KindExclusionComplete, addr, "Exclusion of %s has completed, it is safe to remove.", addr)
+		}
+	}
+
+	for _, id := range sortedKeys(nc.Layers.Backup.Tags) {
+		prevStatus := pc.Layers.Backup.Tags[id].CurrentStatus
+		if nextStatus := nc.Layers.Backup.Tags[id].CurrentStatus; prevStatus != nextStatus {
+			add(KindBackupState, id, "Backup %s changed from %s to %s.", id, stateOrNone(prevStatus), stateOrNone(nextStatus))
+		}
+	}
+
+	for _, id := range sortedKeys(pc.Layers.Backup.Tags) {
+		if _, ok := nc.Layers.Backup.Tags[id]; !ok {
+			add(KindBackupState, id, "Backup %s is no longer reported.", id)
+		}
+	}
+
+	for _, dr := range []struct{ prev, next fdb.DRBackup }{
+		{pc.Layers.DRBackup, nc.Layers.DRBackup},
+		{pc.Layers.DRBackupDest, nc.Layers.DRBackupDest},
+	} {
+		for _, id := range sortedKeys(dr.next.Tags) {
+			prevState := dr.prev.Tags[id].BackupState
+			if nextState := dr.next.Tags[id].BackupState; prevState != nextState {
+				add(KindDRBackupState, id, "DR backup %s changed from %s to %s.", id, stateOrNone(prevState), stateOrNone(nextState))
+			}
+		}
+
+		if !dr.prev.Paused && dr.next.Paused {
+			add(KindDRBackupState, process.ClusterSubject, "DR backups paused.")
+		} else if dr.prev.Paused && !dr.next.Paused {
+			add(KindDRBackupState, process.ClusterSubject, "DR backups resumed.")
+		}
+	}
+
+	return detected
+}
+
+func processesByAddress(processes map[string]fdb.Process) map[string]fdb.Process {
+	byAddress := make(map[string]fdb.Process, len(processes))
+
+	for _, p := range processes {
+		byAddress[p.Address] = p
+	}
+
+	return byAddress
+}
+
+func health(p fdb.Process) process.Health {
+	var m process.Metadata
+	m.Update(p)
+
+	return m.Health
+}
+
+func roleNames(p fdb.Process) []string {
+	var roles []string
+
+	for _, r := range p.Roles {
+		roles = append(roles, r.Role)
+	}
+
+	return roles
+}
+
+func dataState(s fdb.State) string {
+	if s.Health {
+		return s.Name
+	}
+
+	return s.Name + " (unhealthy)"
+}
+
+func stateOrNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+
+	return s
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
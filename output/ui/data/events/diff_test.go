@@ -0,0 +1,100 @@
+package events
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+func update(processes ...fdb.Process) process.Update {
+	u := process.Update{}
+	u.Root.Cluster.Processes = make(map[string]fdb.Process)
+
+	for _, p := range processes {
+		u.Root.Cluster.Processes[p.Address] = p
+	}
+
+	return u
+}
+
+func TestDiffProcesses(t *testing.T) {
+	now := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	healthy := fdb.Process{Address: "10.0.0.1:4500", Class: "storage", Uptime: 100}
+
+	degraded := healthy
+	degraded.Degraded = true
+
+	warning := healthy
+	warning.Messages = []fdb.Message{{Name: "io_error"}}
+
+	excluded := healthy
+	excluded.Excluded = true
+
+	restarted := healthy
+	restarted.Uptime = 5
+
+	tests := []struct {
+		name     string
+		prev     fdb.Process
+		next     fdb.Process
+		expected []Event
+	}{
+		{
+			name: "unchanged",
+			prev: healthy,
+			next: healthy,
+		},
+		{
+			name: "became degraded",
+			prev: healthy,
+			next: degraded,
+			expected: []Event{
+				{Time: now, Kind: KindProcessHealth, Subject: "10.0.0.1:4500", Description: "Process 10.0.0.1:4500 health changed from normal to critical."},
+			},
+		},
+		{
+			name: "recovered from degraded",
+			prev: degraded,
+			next: healthy,
+			expected: []Event{
+				{Time: now, Kind: KindProcessHealth, Subject: "10.0.0.1:4500", Description: "Process 10.0.0.1:4500 health changed from critical to normal."},
+			},
+		},
+		{
+			name: "reported a message",
+			prev: healthy,
+			next: warning,
+			expected: []Event{
+				{Time: now, Kind: KindProcessHealth, Subject: "10.0.0.1:4500", Description: "Process 10.0.0.1:4500 health changed from normal to warning."},
+			},
+		},
+		{
+			name: "excluded",
+			prev: healthy,
+			next: excluded,
+			expected: []Event{
+				{Time: now, Kind: KindProcessHealth, Subject: "10.0.0.1:4500", Description: "Process 10.0.0.1:4500 health changed from normal to excluded."},
+				{Time: now, Kind: KindExcluded, Subject: "10.0.0.1:4500", Description: "Process 10.0.0.1:4500 is now excluded."},
+			},
+		},
+		{
+			name: "restarted",
+			prev: healthy,
+			next: restarted,
+			expected: []Event{
+				{Time: now, Kind: KindProcessRestarted, Subject: "10.0.0.1:4500", Description: "Process 10.0.0.1:4500 restarted, uptime went from 100s to 5s."},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if actual := Diff(update(test.prev), update(test.next), now); !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Diff() = %+v, want %+v", actual, test.expected)
+			}
+		})
+	}
+}
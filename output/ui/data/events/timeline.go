@@ -0,0 +1,93 @@
+package events
+
+import (
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+const (
+	KindProcessAdded      = "process_added"
+	KindProcessRemoved    = "process_removed"
+	KindProcessRestarted  = "process_restarted"
+	KindProcessHealth     = "process_health"
+	KindRoleRecruited     = "role_recruited"
+	KindRoleRemoved       = "role_removed"
+	KindRecoveryState     = "recovery_state"
+	KindAvailability      = "availability"
+	KindDataState         = "data_state"
+	KindExcluded          = "excluded"
+	KindIncluded          = "included"
+	KindExclusionComplete = "exclusion_complete"
+	KindBackupState       = "backup_state"
+	KindDRBackupState     = "dr_backup_state"
+)
+
+type Event struct {
+	Time        time.Time `json:"time"`
+	Kind        string    `json:"kind"`
+	Subject     string    `json:"subject"`
+	Description string    `json:"description"`
+}
+
+type Timeline struct {
+	m        *sync.RWMutex
+	events   *history.Ring[Event]
+	previous *process.Update
+}
+
+func New(capacity int) *Timeline {
+	return &Timeline{
+		m:      &sync.RWMutex{},
+		events: history.NewRing[Event](capacity),
+	}
+}
+
+func (t *Timeline) Record(u process.Update, now time.Time) []Event {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	var detected []Event
+
+	if t.previous != nil {
+		detected = Diff(*t.previous, u, now)
+		for _, e := range detected {
+			t.events.Push(e)
+		}
+	}
+
+	t.previous = &u
+
+	return detected
+}
+
+func (t *Timeline) Reset() {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.events.Reset()
+	t.previous = nil
+}
+
+func (t *Timeline) Events() []Event {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	return t.events.Values()
+}
+
+func (t *Timeline) WriteJSONLines(w io.Writer) error {
+	enc := json.NewEncoder(w)
+
+	for _, e := range t.Events() {
+		if err := enc.Encode(e); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,152 @@
+package history
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+type ClusterSample struct {
+	Time time.Time
+
+	TxStarted    float64
+	TxCommitted  float64
+	TxConflicted float64
+	TxRejected   float64
+
+	Reads        float64
+	Writes       float64
+	BytesRead    float64
+	BytesWritten float64
+
+	MovingDataQueued   float64
+	MovingDataInFlight float64
+}
+
+type ProcessSample struct {
+	Time time.Time
+
+	CPU         float64
+	DiskBusy    float64
+	MemoryUsed  float64
+	NetworkSent float64
+	NetworkRecv float64
+
+	StorageDataLag       float64
+	StorageDurabilityLag float64
+	KVUsedBytes          float64
+	LogQueueLength       float64
+}
+
+type History struct {
+	m        *sync.RWMutex
+	capacity int
+
+	cluster   *Ring[ClusterSample]
+	processes map[string]*Ring[ProcessSample]
+}
+
+func New(capacity int) *History {
+	return &History{
+		m:         &sync.RWMutex{},
+		capacity:  capacity,
+		cluster:   NewRing[ClusterSample](capacity),
+		processes: make(map[string]*Ring[ProcessSample]),
+	}
+}
+
+func (h *History) Record(u process.Update) {
+	now := time.Now()
+
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	workload := u.Root.Cluster.Workload
+	movingData := u.Root.Cluster.Data.MovingData
+
+	h.cluster.Push(ClusterSample{
+		Time:               now,
+		TxStarted:          workload.Transactions.Started.Hz,
+		TxCommitted:        workload.Transactions.Committed.Hz,
+		TxConflicted:       workload.Transactions.Conflicted.Hz,
+		TxRejected:         workload.Transactions.RejectedForQueuedTooLong.Hz,
+		Reads:              workload.Operations.Reads.Hz,
+		Writes:             workload.Operations.Writes.Hz,
+		BytesRead:          workload.Bytes.Read.Hz,
+		BytesWritten:       workload.Bytes.Written.Hz,
+		MovingDataQueued:   float64(movingData.InQueueBytes),
+		MovingDataInFlight: float64(movingData.InFlightBytes),
+	})
+
+	seen := make(map[string]struct{})
+
+	for _, proc := range u.Root.Cluster.Processes {
+		seen[proc.Address] = struct{}{}
+
+		ring, ok := h.processes[proc.Address]
+		if !ok {
+			ring = NewRing[ProcessSample](h.capacity)
+			h.processes[proc.Address] = ring
+		}
+
+		ring.Push(newProcessSample(now, proc))
+	}
+
+	for addr := range h.processes {
+		if _, ok := seen[addr]; !ok {
+			delete(h.processes, addr)
+		}
+	}
+}
+
+func newProcessSample(now time.Time, proc fdb.Process) ProcessSample {
+	sample := ProcessSample{
+		Time:        now,
+		CPU:         proc.CPU.UsageCores,
+		DiskBusy:    proc.Disk.Busy,
+		MemoryUsed:  float64(proc.Memory.RSSBytes),
+		NetworkSent: proc.Network.MegabitsSent.Hz,
+		NetworkRecv: proc.Network.MegabitsReceived.Hz,
+	}
+
+	for _, role := range proc.Roles {
+		switch role.Role {
+		case "storage":
+			sample.StorageDataLag = role.DataLag.Seconds
+			sample.StorageDurabilityLag = role.DurabilityLag.Seconds
+			sample.KVUsedBytes = role.KVUsedBytes
+		case "log":
+			sample.LogQueueLength = role.InputBytes.Counter - role.DurableBytes.Counter
+		}
+	}
+
+	return sample
+}
+
+func (h *History) Reset() {
+	h.m.Lock()
+	defer h.m.Unlock()
+
+	h.cluster.Reset()
+	h.processes = make(map[string]*Ring[ProcessSample])
+}
+
+func (h *History) Cluster() []ClusterSample {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	return h.cluster.Values()
+}
+
+func (h *History) Process(address string) []ProcessSample {
+	h.m.RLock()
+	defer h.m.RUnlock()
+
+	if ring, ok := h.processes[address]; ok {
+		return ring.Values()
+	}
+
+	return nil
+}
@@ -0,0 +1,55 @@
+package history
+
+type Ring[T any] struct {
+	data  []T
+	start int
+	size  int
+}
+
+func NewRing[T any](capacity int) *Ring[T] {
+	if capacity < 1 {
+		capacity = 1
+	}
+
+	return &Ring[T]{data: make([]T, capacity)}
+}
+
+func (r *Ring[T]) Push(v T) {
+	if r.size < len(r.data) {
+		r.data[(r.start+r.size)%len(r.data)] = v
+		r.size++
+	} else {
+		r.data[r.start] = v
+		r.start = (r.start + 1) % len(r.data)
+	}
+}
+
+func (r *Ring[T]) Reset() {
+	clear(r.data)
+	r.start = 0
+	r.size = 0
+}
+
+func (r *Ring[T]) Len() int {
+	return r.size
+}
+
+func (r *Ring[T]) Values() []T {
+	values := make([]T, r.size)
+
+	for i := 0; i < r.size; i++ {
+		values[i] = r.data[(r.start+i)%len(r.data)]
+	}
+
+	return values
+}
+
+func Series[T any](samples []T, fn func(T) float64) []float64 {
+	series := make([]float64, len(samples))
+
+	for i, s := range samples {
+		series[i] = fn(s)
+	}
+
+	return series
+}
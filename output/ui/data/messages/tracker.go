@@ -0,0 +1,139 @@
+package messages
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+type Entry struct {
+	Source      string
+	Class       string
+	Name        string
+	Description string
+	FirstSeen   time.Time
+	LastSeen    time.Time
+	Active      bool
+}
+
+type key struct {
+	source string
+	name   string
+}
+
+type Tracker struct {
+	m       *sync.RWMutex
+	entries map[key]*Entry
+}
+
+func NewTracker() *Tracker {
+	return &Tracker{
+		m:       &sync.RWMutex{},
+		entries: make(map[key]*Entry),
+	}
+}
+
+func (t *Tracker) Record(root fdb.Root, now time.Time) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	for _, e := range t.entries {
+		e.Active = false
+	}
+
+	for _, msg := range root.Cluster.Messages {
+		t.observe(process.ClusterSubject, "", msg, now)
+	}
+
+	present := make(map[string]bool)
+
+	for _, proc := range root.Cluster.Processes {
+		present[proc.Address] = true
+
+		for _, msg := range proc.Messages {
+			t.observe(proc.Address, proc.Class, msg, now)
+		}
+	}
+
+	for k := range t.entries {
+		if k.source != process.ClusterSubject && !present[k.source] {
+			delete(t.entries, k)
+		}
+	}
+}
+
+func (t *Tracker) observe(source string, class string, msg fdb.Message, now time.Time) {
+	k := key{source: source, name: msg.Name}
+
+	e, ok := t.entries[k]
+	if !ok {
+		e = &Entry{Source: source, Name: msg.Name, FirstSeen: now}
+		t.entries[k] = e
+	}
+
+	e.Class = class
+	e.Description = msg.Description
+	e.LastSeen = now
+	e.Active = true
+}
+
+func (t *Tracker) Reset() {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.entries = make(map[key]*Entry)
+}
+
+func (t *Tracker) Entries() []Entry {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	entries := make([]Entry, 0, len(t.entries))
+	for _, e := range t.entries {
+		entries = append(entries, *e)
+	}
+
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Active != entries[j].Active {
+			return entries[i].Active
+		}
+
+		if !entries[i].LastSeen.Equal(entries[j].LastSeen) {
+			return entries[i].LastSeen.After(entries[j].LastSeen)
+		}
+
+		if (entries[i].Source == process.ClusterSubject) != (entries[j].Source == process.ClusterSubject) {
+			return entries[i].Source == process.ClusterSubject
+		}
+
+		if entries[i].Source != entries[j].Source {
+			return entries[i].Source < entries[j].Source
+		}
+
+		return entries[i].Name < entries[j].Name
+	})
+
+	return entries
+}
+
+func (t *Tracker) Names() []string {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	seen := make(map[string]struct{})
+	var names []string
+
+	for k := range t.entries {
+		if _, ok := seen[k.name]; !ok {
+			seen[k.name] = struct{}{}
+			names = append(names, k.name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
@@ -6,8 +6,13 @@ type Update struct {
 	Root                fdb.Root
 	ExcludedProcesses   []string
 	ExclusionInProgress []string
+	AlertedProcesses    map[string]Health
 }
 
+// ClusterSubject names the cluster itself, rather than a process, as the
+// subject of an event, alert or message.
+const ClusterSubject = "cluster"
+
 type Process struct {
 	FDBData  *fdb.Process
 	Metadata *Metadata
@@ -23,10 +28,27 @@ const (
 	HealthExcludedOnly
 )
 
+var healthNames = map[Health]string{
+	HealthCritical:     "critical",
+	HealthWarning:      "warning",
+	HealthNormal:       "normal",
+	HealthExcluded:     "excluded",
+	HealthExcludedOnly: "excluded_only",
+}
+
+func (h Health) String() string {
+	return healthNames[h]
+}
+
 type Metadata struct {
 	Health              Health
 	Selected            bool
 	ExclusionInProgress bool
+	LatencyOutliers     map[string]bool
+}
+
+func (m *Metadata) LatencyOutlier(role string) bool {
+	return m.LatencyOutliers[role]
 }
 
 func (m *Metadata) ToggleSelected() {
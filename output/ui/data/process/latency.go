@@ -0,0 +1,50 @@
+package process
+
+import "sort"
+
+const (
+	LatencyOutlierFactor   = 3.0
+	latencyOutlierMinPeers = 3
+)
+
+func markLatencyOutliers(processes []*Process) {
+	peers := make(map[string][]float64)
+
+	for _, p := range processes {
+		p.Metadata.LatencyOutliers = nil
+
+		for _, r := range p.FDBData.Roles {
+			if _, stats, ok := r.LatencyStatistics(); ok && stats.Count > 0 {
+				peers[r.Role] = append(peers[r.Role], stats.P99)
+			}
+		}
+	}
+
+	medians := make(map[string]float64)
+
+	for role, p99s := range peers {
+		if len(p99s) < latencyOutlierMinPeers {
+			continue
+		}
+
+		sort.Float64s(p99s)
+		medians[role] = p99s[len(p99s)/2]
+	}
+
+	for _, p := range processes {
+		for _, r := range p.FDBData.Roles {
+			median, found := medians[r.Role]
+			if !found {
+				continue
+			}
+
+			if _, stats, ok := r.LatencyStatistics(); ok && stats.Count > 0 && stats.P99 > median*LatencyOutlierFactor {
+				if p.Metadata.LatencyOutliers == nil {
+					p.Metadata.LatencyOutliers = make(map[string]bool)
+				}
+
+				p.Metadata.LatencyOutliers[r.Role] = true
+			}
+		}
+	}
+}
@@ -25,6 +25,29 @@ func NewStore(sortFn func(Process, Process) bool) *Store {
 		sortFn: sortFn,
 	}
 }
+
+// Processes derives the processes in root without any store state, such as
+// selection or exclusions in progress.
+func Processes(root fdb.Root) []Process {
+	var ptrs []*Process
+
+	for _, proc := range root.Cluster.Processes {
+		copyProc := proc
+		p := &Process{FDBData: &copyProc, Metadata: &Metadata{}}
+		p.Metadata.Update(proc)
+		ptrs = append(ptrs, p)
+	}
+
+	markLatencyOutliers(ptrs)
+
+	processes := make([]Process, 0, len(ptrs))
+	for _, p := range ptrs {
+		processes = append(processes, *p)
+	}
+
+	return processes
+}
+
 func (m *Store) AddNotifiable(updateFn func([]Process), filterFn func(Process) bool) {
 	m.notifiables = append(m.notifiables, notifiable{
 		updateFn: updateFn,
@@ -41,6 +64,10 @@ func (m *Store) Update(u Update) {
 		p.FDBData = &copyProc
 		p.Metadata.Update(proc)
 		p.Metadata.ExclusionInProgress = false
+
+		if h, ok := u.AlertedProcesses[proc.Address]; ok && h < p.Metadata.Health {
+			p.Metadata.Health = h
+		}
 	}
 
 	for _, excluding := range u.ExclusionInProgress {
@@ -65,6 +92,8 @@ func (m *Store) Update(u Update) {
 
 	m.data = nd
 
+	markLatencyOutliers(m.data)
+
 	m.notify()
 }
 
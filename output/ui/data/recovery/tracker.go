@@ -0,0 +1,135 @@
+package recovery
+
+import (
+	"sync"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
+)
+
+type Recovery struct {
+	Start      time.Time
+	End        time.Time
+	Stages     []string
+	Generation int
+	Master     string
+	InProgress bool
+}
+
+func (r Recovery) Duration(now time.Time) (time.Duration, bool) {
+	if r.Start.IsZero() {
+		return 0, false
+	}
+
+	if r.InProgress {
+		return now.Sub(r.Start), true
+	}
+
+	return r.End.Sub(r.Start), true
+}
+
+type Tracker struct {
+	m          *sync.RWMutex
+	recoveries *history.Ring[Recovery]
+	current    *Recovery
+	generation int
+	observed   bool
+}
+
+func NewTracker(capacity int) *Tracker {
+	return &Tracker{
+		m:          &sync.RWMutex{},
+		recoveries: history.NewRing[Recovery](capacity),
+	}
+}
+
+func (t *Tracker) Record(root fdb.Root, now time.Time) {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	state := root.Cluster.RecoveryState
+	generation := root.Cluster.Generation
+	master := masterAddress(root)
+
+	switch {
+	case state.Name == "":
+		return
+	case state.Name != fdb.RecoveryStateFullyRecovered:
+		if t.current == nil {
+			t.current = &Recovery{Start: now, InProgress: true}
+		}
+
+		if len(t.current.Stages) == 0 || t.current.Stages[len(t.current.Stages)-1] != state.Name {
+			t.current.Stages = append(t.current.Stages, state.Name)
+		}
+
+		t.current.Generation = generation
+		t.current.Master = master
+	case t.current != nil:
+		t.current.End = recoveredAt(state, now)
+		if t.current.End.Before(t.current.Start) {
+			t.current.End = now
+		}
+
+		t.current.Stages = append(t.current.Stages, state.Name)
+		t.current.Generation = generation
+		t.current.Master = master
+		t.current.InProgress = false
+
+		t.recoveries.Push(*t.current)
+		t.current = nil
+	case !t.observed || generation > t.generation:
+		if state.SecondsSinceLastRecovered > 0 {
+			t.recoveries.Push(Recovery{
+				End:        recoveredAt(state, now),
+				Stages:     []string{state.Name},
+				Generation: generation,
+				Master:     master,
+			})
+		}
+	}
+
+	t.generation = generation
+	t.observed = true
+}
+
+func (t *Tracker) Reset() {
+	t.m.Lock()
+	defer t.m.Unlock()
+
+	t.recoveries.Reset()
+	t.current = nil
+	t.generation = 0
+	t.observed = false
+}
+
+func (t *Tracker) Recoveries() []Recovery {
+	t.m.RLock()
+	defer t.m.RUnlock()
+
+	recoveries := t.recoveries.Values()
+	if t.current != nil {
+		current := *t.current
+		current.Stages = append([]string(nil), t.current.Stages...)
+		recoveries = append(recoveries, current)
+	}
+
+	return recoveries
+}
+
+func recoveredAt(state fdb.RecoveryState, now time.Time) time.Time {
+	return now.Add(-time.Duration(state.SecondsSinceLastRecovered * float64(time.Second)))
+}
+
+func masterAddress(root fdb.Root) string {
+	for _, p := range root.Cluster.Processes {
+		for _, r := range p.Roles {
+			if r.Role == "master" {
+				return p.Address
+			}
+		}
+	}
+
+	return ""
+}
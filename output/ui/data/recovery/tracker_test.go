@@ -0,0 +1,131 @@
+package recovery
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+)
+
+type snapshot struct {
+	at         time.Duration
+	name       string
+	since      float64
+	generation int
+}
+
+func (s snapshot) root() fdb.Root {
+	root := fdb.Root{}
+	root.Cluster.Generation = s.generation
+	root.Cluster.RecoveryState = fdb.RecoveryState{Name: s.name, SecondsSinceLastRecovered: s.since}
+	root.Cluster.Processes = map[string]fdb.Process{
+		"a": {Address: "10.0.0.1:4500", Roles: []fdb.Role{{Role: "master"}}},
+	}
+
+	return root
+}
+
+func TestTrackerRecord(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name      string
+		snapshots []snapshot
+		expected  []Recovery
+	}{
+		{
+			name:      "first observation records the last recovery",
+			snapshots: []snapshot{{name: fdb.RecoveryStateFullyRecovered, since: 60, generation: 4}},
+			expected: []Recovery{
+				{End: start.Add(-60 * time.Second), Stages: []string{fdb.RecoveryStateFullyRecovered}, Generation: 4, Master: "10.0.0.1:4500"},
+			},
+		},
+		{
+			name: "unchanged generation is not recorded again",
+			snapshots: []snapshot{
+				{name: fdb.RecoveryStateFullyRecovered, since: 60, generation: 4},
+				{at: 10 * time.Second, name: fdb.RecoveryStateFullyRecovered, since: 70, generation: 4},
+			},
+			expected: []Recovery{
+				{End: start.Add(-60 * time.Second), Stages: []string{fdb.RecoveryStateFullyRecovered}, Generation: 4, Master: "10.0.0.1:4500"},
+			},
+		},
+		{
+			name: "recovery between refreshes is recorded by generation",
+			snapshots: []snapshot{
+				{name: fdb.RecoveryStateFullyRecovered, since: 60, generation: 4},
+				{at: 10 * time.Second, name: fdb.RecoveryStateFullyRecovered, since: 2, generation: 6},
+			},
+			expected: []Recovery{
+				{End: start.Add(-60 * time.Second), Stages: []string{fdb.RecoveryStateFullyRecovered}, Generation: 4, Master: "10.0.0.1:4500"},
+				{End: start.Add(8 * time.Second), Stages: []string{fdb.RecoveryStateFullyRecovered}, Generation: 6, Master: "10.0.0.1:4500"},
+			},
+		},
+		{
+			name: "observed recovery records its stages",
+			snapshots: []snapshot{
+				{name: "reading_coordinated_state", generation: 5},
+				{at: 1 * time.Second, name: "reading_coordinated_state", generation: 5},
+				{at: 2 * time.Second, name: "accepting_commits", generation: 6},
+				{at: 5 * time.Second, name: fdb.RecoveryStateFullyRecovered, since: 1, generation: 6},
+			},
+			expected: []Recovery{
+				{
+					Start:      start,
+					End:        start.Add(4 * time.Second),
+					Stages:     []string{"reading_coordinated_state", "accepting_commits", fdb.RecoveryStateFullyRecovered},
+					Generation: 6,
+					Master:     "10.0.0.1:4500",
+				},
+			},
+		},
+		{
+			name: "recovery in progress is current",
+			snapshots: []snapshot{
+				{name: fdb.RecoveryStateFullyRecovered, since: 0, generation: 4},
+				{at: 3 * time.Second, name: "recruiting_transaction_servers", generation: 5},
+			},
+			expected: []Recovery{
+				{Start: start.Add(3 * time.Second), Stages: []string{"recruiting_transaction_servers"}, Generation: 5, Master: "10.0.0.1:4500", InProgress: true},
+			},
+		},
+		{
+			name:      "missing recovery state is ignored",
+			snapshots: []snapshot{{generation: 4}},
+			expected:  []Recovery{},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			tracker := NewTracker(10)
+
+			for _, s := range test.snapshots {
+				tracker.Record(s.root(), start.Add(s.at))
+			}
+
+			if actual := tracker.Recoveries(); !reflect.DeepEqual(actual, test.expected) {
+				t.Errorf("Recoveries() = %+v, want %+v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestTrackerReset(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	tracker := NewTracker(10)
+
+	tracker.Record(snapshot{name: fdb.RecoveryStateFullyRecovered, since: 60, generation: 4}.root(), start)
+	tracker.Reset()
+
+	if actual := tracker.Recoveries(); len(actual) != 0 {
+		t.Fatalf("Recoveries() after Reset() = %+v, want none", actual)
+	}
+
+	tracker.Record(snapshot{name: fdb.RecoveryStateFullyRecovered, since: 60, generation: 2}.root(), start)
+
+	if actual := tracker.Recoveries(); len(actual) != 1 || actual[0].Generation != 2 {
+		t.Errorf("Recoveries() = %+v, want the recovery of generation 2", actual)
+	}
+}
@@ -1,42 +1,89 @@
 package ui
 
 import (
-	"encoding/json"
+	"flag"
 	"fmt"
 	"os"
 	"strings"
+	"sync/atomic"
 	"time"
 
 	"github.com/gdamore/tcell/v2"
 	"github.com/pwood/fdbexplorer/data/fdb"
 	"github.com/pwood/fdbexplorer/input"
 	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/alerts"
+	"github.com/pwood/fdbexplorer/output/ui/data/events"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
+	"github.com/pwood/fdbexplorer/output/ui/data/messages"
 	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/data/recovery"
 	"github.com/pwood/fdbexplorer/output/ui/panels"
 	"github.com/pwood/fdbexplorer/output/ui/views"
 	"github.com/rivo/tview"
 )
 
+const recoveryHistorySize = 100
+
+var historySize *int
+var eventHistorySize *int
+var alertRules *string
+var alertBell *bool
+
+func init() {
+	historySize = flag.Int("history-size", 120, "Number of refreshes of cluster and process metrics to keep in memory for trends.")
+	alertRules = flag.String("alert-rules", "", "Location of a JSON file of alert rules to evaluate on every refresh.")
+	alertBell = flag.Bool("alert-bell", false, "Ring the terminal bell when an alert rule starts firing.")
+	eventHistorySize = flag.Int("event-history-size", 1000, "Number of events detected between refreshes to keep in memory for the event timeline.")
+}
+
 func New(ds input.StatusProvider) *Main {
-	main := &Main{ds: ds, upCh: make(chan struct{})}
+	main := &Main{ds: ds, upCh: make(chan struct{}), switchCh: make(chan struct{})}
 
 	if em, ok := ds.(input.ExclusionManager); ok {
 		main.em = em
 	}
 
+	if cs, ok := ds.(input.ClusterSwitcher); ok {
+		main.cs = cs
+	}
+
 	return main
 }
 
+type Publisher interface {
+	Publish([]byte)
+	Fail(error)
+}
+
 type Main struct {
 	ds   input.StatusProvider
 	em   input.ExclusionManager
+	cs   input.ClusterSwitcher
 	upCh chan struct{}
 	app  *tview.Application
 
+	switchCh chan struct{}
+	// generation is bumped on each cluster switch, so that data fetched from
+	// the previous cluster is discarded rather than recorded.
+	generation atomic.Uint64
+
+	publishers []Publisher
+	streaming  bool
+
+	screen tcell.Screen
+
 	slideShow *components.SlideShow
+	pages     *tview.Pages
+	detail    *panels.ProcessDetailPanel
 	sorter    *process.SortControl
 
 	processStore *process.Store
+	history      *history.History
+	events       *events.Timeline
+	recoveries   *recovery.Tracker
+	messages     *messages.Tracker
+	alerts       *alerts.Engine
 	panels       []panels.Panel
 	rawJson      []byte
 
@@ -50,6 +97,10 @@ const (
 	StatusFailure    = tcell.ColorRed
 )
 
+func (m *Main) AddPublisher(p Publisher) {
+	m.publishers = append(m.publishers, p)
+}
+
 func (m *Main) updateStatus(message string, colour tcell.Color) {
 	go m.app.QueueUpdateDraw(func() {
 		text := []string{"[", time.Now().Format("15:04:05"), "] ", message}
@@ -58,12 +109,32 @@ func (m *Main) updateStatus(message string, colour tcell.Color) {
 }
 
 func (m *Main) runData() {
+	var updated <-chan struct{}
+	if sn, ok := m.ds.(input.StatusNotifier); ok {
+		updated = sn.Updated()
+	}
+	m.streaming = updated != nil
+
 	m.updateFromDS()
 
 	for {
+		var interval <-chan time.Time
+		if updated == nil {
+			interval = time.After(m.interval.Duration())
+		}
+
 		select {
-		case <-time.After(m.interval.Duration()):
+		case <-interval:
 		case <-m.upCh:
+		case <-updated:
+		case <-m.switchCh:
+			m.nextCluster()
+
+			// A stream only has the new cluster once it has sent its first
+			// snapshot, which arrives through updated.
+			if m.streaming {
+				continue
+			}
 		}
 
 		m.updateFromDS()
@@ -73,30 +144,24 @@ func (m *Main) runData() {
 func (m *Main) updateFromDS() {
 	m.updateStatus("Updating data...", StatusInProgress)
 	start := time.Now()
+	generation := m.generation.Load()
 
 	d, err := m.ds.Status()
 	if err != nil {
+		for _, p := range m.publishers {
+			p.Fail(err)
+		}
+
 		m.updateStatus(fmt.Sprintf("Failed to query Root data source: %s", err.Error()), StatusFailure)
 		return
 	}
 
-	var root fdb.Root
-	if err := json.Unmarshal(d, &root); err != nil {
+	root, err := fdb.Decode(d)
+	if err != nil {
 		m.updateStatus(fmt.Sprintf("Failed to unmarshal data: %s", err.Error()), StatusFailure)
 		return
 	}
 
-	newProcesses := map[string]fdb.Process{}
-
-	for id, p := range root.Cluster.Processes {
-		newAddress, tls := strings.CutSuffix(p.Address, ":tls")
-		p.Address = newAddress
-		p.TLS = tls
-		newProcesses[id] = p
-	}
-
-	root.Cluster.Processes = newProcesses
-
 	u := process.Update{
 		Root: root,
 	}
@@ -117,13 +182,37 @@ func (m *Main) updateFromDS() {
 		}
 	}
 
+	if generation != m.generation.Load() {
+		return
+	}
+
 	m.rawJson = d
+
+	for _, p := range m.publishers {
+		p.Publish(d)
+	}
 	duration := time.Since(start)
 
 	msg := fmt.Sprintf("Updated in %dms, next in %s.", duration.Milliseconds(), m.interval.Duration().String())
+	if m.streaming {
+		msg = fmt.Sprintf("Updated in %dms, next when streamed.", duration.Milliseconds())
+	}
 	m.updateStatus(msg, StatusSuccess)
 
 	m.app.QueueUpdateDraw(func() {
+		if generation != m.generation.Load() {
+			return
+		}
+
+		if started := m.alerts.Evaluate(u.Root, time.Now()); len(started) > 0 && m.screen != nil {
+			_ = m.screen.Beep()
+		}
+		u.AlertedProcesses = m.alerts.ProcessHealth()
+
+		m.history.Record(u)
+		m.events.Record(u, time.Now())
+		m.recoveries.Record(u.Root, time.Now())
+		m.messages.Record(u.Root, time.Now())
 		m.processStore.Update(u)
 		for _, p := range m.panels {
 			p.Update(u)
@@ -131,6 +220,38 @@ func (m *Main) updateFromDS() {
 	})
 }
 
+func (m *Main) nextCluster() {
+	names, err := m.cs.Clusters()
+	if err != nil {
+		m.updateStatus(fmt.Sprintf("Failed to list clusters: %s", err.Error()), StatusFailure)
+		return
+	}
+
+	next := names[0]
+	for i, name := range names {
+		if name == m.cs.Cluster() {
+			next = names[(i+1)%len(names)]
+		}
+	}
+
+	if err := m.cs.SwitchCluster(next); err != nil {
+		m.updateStatus(fmt.Sprintf("Failed to switch cluster: %s", err.Error()), StatusFailure)
+		return
+	}
+
+	m.generation.Add(1)
+	m.updateStatus(fmt.Sprintf("Switched to cluster %s, updating data...", next), StatusInProgress)
+
+	m.app.QueueUpdateDraw(func() {
+		m.history.Reset()
+		m.events.Reset()
+		m.recoveries.Reset()
+		m.messages.Reset()
+		m.alerts.Reset()
+		m.processStore.ClearSelected()
+	})
+}
+
 func (m *Main) snapshotData() (string, error) {
 	fileName := fmt.Sprintf("fdbexplorer-status-snapshot-%d.json", time.Now().Unix())
 
@@ -152,29 +273,111 @@ func (m *Main) snapshotData() (string, error) {
 	return fileName, nil
 }
 
+func (m *Main) exportEvents() (string, error) {
+	fileName := fmt.Sprintf("fdbexplorer-events-%d.jsonl", time.Now().Unix())
+
+	f, err := os.Create(fileName)
+	defer func() {
+		_ = f.Close()
+	}()
+
+	if err != nil {
+		return "", fmt.Errorf("open: %w", err)
+	}
+
+	if err := m.events.WriteJSONLines(f); err != nil {
+		return "", fmt.Errorf("write: %w", err)
+	}
+
+	return fileName, nil
+}
+
+const (
+	pageSlideShow = "slideshow"
+	pageDetail    = "detail"
+)
+
+func (m *Main) openDetail(processes []process.Process) {
+	m.detail.Show(processes)
+	m.pages.SwitchToPage(pageDetail)
+	m.app.SetFocus(m.detail.Root())
+}
+
+func (m *Main) closeDetail() {
+	m.pages.SwitchToPage(pageSlideShow)
+	m.app.SetFocus(m.slideShow)
+}
+
+func (m *Main) detailOpen() bool {
+	name, _ := m.pages.GetFrontPage()
+	return name == pageDetail
+}
+
 func (m *Main) Run() {
 	m.interval = &views.IntervalControl{}
 	m.sorter = &process.SortControl{}
 	m.processStore = process.NewStore(m.sorter.Sort)
+	m.history = history.New(*historySize)
+	m.events = events.New(*eventHistorySize)
+	m.recoveries = recovery.NewTracker(recoveryHistorySize)
+	m.messages = messages.NewTracker()
+
+	var rules []alerts.Rule
+	if *alertRules != "" {
+		var err error
+		if rules, err = alerts.LoadRules(*alertRules); err != nil {
+			fmt.Fprintf(os.Stderr, "Failed to load alert rules: %s\n", err.Error())
+			os.Exit(1)
+		}
+	}
+	m.alerts = alerts.NewEngine(rules)
+
+	m.detail = panels.NewProcessDetail(m.processStore, m.history)
 
-	locality := panels.NewLocality(m.processStore)
-	usage := panels.NewUsage(m.processStore)
-	storage := panels.NewStorage(m.processStore)
-	logs := panels.NewLogs(m.processStore)
+	locality := panels.NewLocality(m.processStore, m.openDetail)
+	topology := panels.NewTopology(m.processStore, m.openDetail)
+	heatmap := panels.NewHeatmap(m.processStore, m.openDetail)
+	usage := panels.NewUsage(m.processStore, m.openDetail)
+	storage := panels.NewStorage(m.processStore, m.openDetail)
+	logs := panels.NewLogs(m.processStore, m.openDetail)
 	backups := panels.NewBackups()
 	drBackups := panels.NewDRBackups()
-	clusterHealth := panels.NewClusterHealth()
-	clusterWorkload := panels.NewClusterWorkload()
-
-	m.panels = []panels.Panel{backups, drBackups, clusterHealth, clusterWorkload}
+	configuration := panels.NewConfiguration()
+	qos := panels.NewQoS(m.processStore, m.openDetail)
+	transaction := panels.NewTransaction(m.processStore, m.openDetail)
+	clients := panels.NewClients()
+	upgrades := panels.NewUpgrades()
+	messagesPanel := panels.NewMessages(m.messages, m.processStore, m.openDetail)
+	timeline := panels.NewEvents(m.events, m.processStore, m.openDetail)
+	recoveries := panels.NewRecoveries(m.recoveries, m.processStore, m.openDetail)
+	alertsPanel := panels.NewAlerts(m.alerts, m.processStore, m.openDetail)
+	clusterHealth := panels.NewClusterHealth(m.history, m.recoveries)
+	clusterWorkload := panels.NewClusterWorkload(m.history)
+
+	m.panels = []panels.Panel{backups, drBackups, configuration, qos, clients, upgrades, messagesPanel, timeline, recoveries, alertsPanel, clusterHealth, clusterWorkload, m.detail}
 
 	m.slideShow = components.NewSlideShow()
 	m.slideShow.Add("Locality", locality.Root())
+	m.slideShow.Add("Topology", topology.Root())
+	m.slideShow.Add("Heatmap", heatmap.Root())
 	m.slideShow.Add("Usage Overview", usage.Root())
+	m.slideShow.Add("Ratekeeper", qos.Root())
 	m.slideShow.Add("Storage Processes", storage.Root())
 	m.slideShow.Add("Log Processes", logs.Root())
+	m.slideShow.Add("Transaction System", transaction.Root())
+	m.slideShow.Add("Clients", clients.Root())
+	m.slideShow.Add("Upgrades", upgrades.Root())
+	m.slideShow.Add("Alerts", alertsPanel.Root())
+	m.slideShow.Add("Messages", messagesPanel.Root())
+	m.slideShow.Add("Events", timeline.Root())
+	m.slideShow.Add("Recoveries", recoveries.Root())
 	m.slideShow.Add("Backups", backups.Root())
 	m.slideShow.Add("DR Backups", drBackups.Root())
+	m.slideShow.Add("Configuration", configuration.Root())
+
+	m.pages = tview.NewPages()
+	m.pages.AddPage(pageSlideShow, m.slideShow, true, true)
+	m.pages.AddPage(pageDetail, m.detail.Root(), true, false)
 
 	m.statusText = tview.NewTextView()
 	m.statusText.SetTextAlign(tview.AlignRight)
@@ -182,19 +385,33 @@ func (m *Main) Run() {
 
 	bottom := tview.NewFlex()
 	bottom.SetBorderPadding(0, 0, 1, 1)
-	bottom.AddItem(tview.NewTable().SetContent(&views.HelpKeys{Sorter: m.sorter, Interval: m.interval, HasEM: m.em != nil}).SetSelectable(false, false), 0, 1, false)
+	helpKeys := &views.HelpKeys{Sorter: m.sorter, Interval: m.interval, HasEM: m.em != nil}
+	if m.cs != nil {
+		helpKeys.Cluster = m.cs.Cluster
+	}
+
+	bottom.AddItem(tview.NewTable().SetContent(helpKeys).SetSelectable(false, false), 0, 1, false)
 	bottom.AddItem(m.statusText, 0, 1, false)
 
 	grid := tview.NewGrid().SetRows(5, 0, 1).SetColumns(0, 0, 0).SetBorders(true)
 	grid.AddItem(clusterHealth.Root(), 0, 0, 1, 2, 0, 0, false)
 	grid.AddItem(clusterWorkload.Root(), 0, 2, 1, 1, 0, 0, false)
-	grid.AddItem(m.slideShow, 1, 0, 1, 3, 0, 0, true)
+	grid.AddItem(m.pages, 1, 0, 1, 3, 0, 0, true)
 	grid.AddItem(bottom, 2, 0, 1, 3, 0, 0, false)
 
 	grid.SetInputCapture(m.rootAction)
 
 	m.app = tview.NewApplication().SetRoot(grid, true).SetFocus(locality.Root())
 
+	if *alertBell {
+		screen, err := tcell.NewScreen()
+		if err != nil {
+			panic(err)
+		}
+		m.screen = screen
+		m.app.SetScreen(screen)
+	}
+
 	go m.runData()
 
 	if err := m.app.Run(); err != nil {
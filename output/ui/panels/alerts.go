@@ -0,0 +1,78 @@
+package panels
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/alerts"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type AlertsPanel struct {
+	flex    *tview.Flex
+	title   *tview.TextView
+	content *components.DataTable[alerts.Alert]
+	engine  *alerts.Engine
+}
+
+func NewAlerts(engine *alerts.Engine, store *process.Store, open OpenFn) *AlertsPanel {
+	content := components.NewDataTable[alerts.Alert](
+		[]components.ColumnDef[alerts.Alert]{
+			views.ColumnAlertSeverity, views.ColumnAlertRule, views.ColumnAlertSubject,
+			views.ColumnAlertValue, views.ColumnAlertCondition, views.ColumnAlertSince,
+		})
+
+	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyEnter {
+			return event
+		}
+
+		row, _ := table.GetSelection()
+		if row < 1 || row >= content.GetRowCount() {
+			return nil
+		}
+
+		if processes := store.FilterFetch(views.AddressMatch(content.Get(row).Subject)); len(processes) > 0 {
+			open(processes)
+		}
+
+		return nil
+	})
+
+	p := &AlertsPanel{
+		title:   tview.NewTextView().SetDynamicColors(true),
+		content: content,
+		engine:  engine,
+	}
+
+	p.flex = tview.NewFlex()
+	p.flex.SetDirection(tview.FlexRow)
+	p.flex.AddItem(p.title, 1, 0, false)
+	p.flex.AddItem(table, 0, 1, true)
+
+	p.render()
+
+	return p
+}
+
+func (p *AlertsPanel) Root() tview.Primitive { return p.flex }
+
+func (p *AlertsPanel) Update(process.Update) {
+	p.render()
+}
+
+func (p *AlertsPanel) render() {
+	firing := p.engine.Firing()
+
+	if len(p.engine.Rules()) == 0 {
+		p.title.SetText("No alert rules loaded, see -alert-rules.")
+	} else {
+		p.title.SetText(fmt.Sprintf("Rules: %d  Firing: [yellow]%d[-]  (Enter: process detail)", len(p.engine.Rules()), len(firing)))
+	}
+
+	p.content.Update(firing)
+}
@@ -0,0 +1,53 @@
+package panels
+
+import (
+	"fmt"
+
+	"github.com/pwood/fdbexplorer/data/upgrade"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type ClientsPanel struct {
+	flex            *tview.Flex
+	versionsContent *components.DataTable[views.ClientVersionGroup]
+	clientsContent  *components.DataTable[views.Client]
+}
+
+func NewClients() *ClientsPanel {
+	versionsContent := components.NewDataTable[views.ClientVersionGroup](
+		[]components.ColumnDef[views.ClientVersionGroup]{
+			views.ColumnClientVersionGroupVersion, views.ColumnClientVersionGroupProtocol,
+			views.ColumnClientVersionGroupCount, views.ColumnClientVersionGroupMaxProtocol,
+			views.ColumnClientVersionGroupTarget,
+		})
+
+	clientsContent := components.NewDataTable[views.Client](
+		[]components.ColumnDef[views.Client]{
+			views.ColumnClientAddress, views.ColumnClientTLS, views.ColumnClientLogGroup,
+			views.ColumnClientVersion, views.ColumnClientProtocol, views.ColumnClientTarget,
+		})
+
+	versionsTitle := "Client Versions"
+	if target := upgrade.TargetVersion(); target != "" {
+		versionsTitle = fmt.Sprintf("Client Versions (Target %s)", target)
+	}
+
+	clients := tview.NewTable().SetContent(clientsContent).SetFixed(1, 0).SetSelectable(true, false)
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(borderedTable(versionsTitle, versionsContent), 0, 1, false)
+	flex.AddItem(clients, 0, 2, true)
+
+	return &ClientsPanel{flex: flex, versionsContent: versionsContent, clientsContent: clientsContent}
+}
+
+func (p *ClientsPanel) Root() tview.Primitive { return p.flex }
+
+func (p *ClientsPanel) Update(u process.Update) {
+	views.UpdateClientVersions(p.versionsContent.Update)(u)
+	views.UpdateClients(p.clientsContent.Update)(u)
+}
@@ -3,22 +3,26 @@ package panels
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
 	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/data/recovery"
 	"github.com/pwood/fdbexplorer/output/ui/views"
 	"github.com/rivo/tview"
 )
 
 type ClusterHealthPanel struct {
-	flex    *tview.Flex
-	content *components.StatsGrid[views.ClusterHealth]
+	flex       *tview.Flex
+	history    *history.History
+	recoveries *recovery.Tracker
+	content    *components.StatsGrid[views.ClusterHealth]
 }
 
-func NewClusterHealth() *ClusterHealthPanel {
+func NewClusterHealth(h *history.History, r *recovery.Tracker) *ClusterHealthPanel {
 	content := components.NewStatsGrid([][]components.ColumnDef[views.ClusterHealth]{
-		{views.StatClusterHealth, views.StatRebalanceQueued},
-		{views.StatReplicasRemaining, views.StatRebalanceInflight},
-		{views.StatRecoveryState, views.StatEmpty},
-		{views.StatRecoveryDescription, views.StatDatabaseLocked},
+		{views.StatClusterHealth, views.StatRebalanceQueued, views.StatLatencyProbeGRV},
+		{views.StatReplicasRemaining, views.StatRebalanceInflight, views.StatLatencyProbeRead},
+		{views.StatRecoveryState, views.StatFaultTolerance, views.StatLatencyProbeCommit},
+		{views.StatRecoveryDescription, views.StatDatabaseLocked, views.StatRecentRecoveries},
 	})
 
 	flex := tview.NewFlex()
@@ -27,11 +31,11 @@ func NewClusterHealth() *ClusterHealthPanel {
 	flex.AddItem(tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText("Cluster Health").SetTextColor(tcell.ColorAqua), 1, 1, false)
 	flex.AddItem(tview.NewTable().SetContent(content).SetSelectable(false, false), 0, 1, false)
 
-	return &ClusterHealthPanel{flex: flex, content: content}
+	return &ClusterHealthPanel{flex: flex, history: h, recoveries: r, content: content}
 }
 
 func (p *ClusterHealthPanel) Root() tview.Primitive { return p.flex }
 
 func (p *ClusterHealthPanel) Update(u process.Update) {
-	views.UpdateClusterHealth(p.content.Update)(u)
+	views.UpdateClusterHealth(p.history, p.recoveries, p.content.Update)(u)
 }
@@ -3,6 +3,7 @@ package panels
 import (
 	"github.com/gdamore/tcell/v2"
 	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
 	"github.com/pwood/fdbexplorer/output/ui/data/process"
 	"github.com/pwood/fdbexplorer/output/ui/views"
 	"github.com/rivo/tview"
@@ -10,10 +11,11 @@ import (
 
 type ClusterWorkloadPanel struct {
 	flex    *tview.Flex
+	history *history.History
 	content *components.StatsGrid[views.ClusterStats]
 }
 
-func NewClusterWorkload() *ClusterWorkloadPanel {
+func NewClusterWorkload(h *history.History) *ClusterWorkloadPanel {
 	content := components.NewStatsGrid([][]components.ColumnDef[views.ClusterStats]{
 		{views.StatTxStarted, views.StatReads},
 		{views.StatTxCommitted, views.StatWrites},
@@ -27,11 +29,11 @@ func NewClusterWorkload() *ClusterWorkloadPanel {
 	flex.AddItem(tview.NewTextView().SetTextAlign(tview.AlignCenter).SetText("Cluster Workload").SetTextColor(tcell.ColorAqua), 1, 1, false)
 	flex.AddItem(tview.NewTable().SetContent(content).SetSelectable(false, false), 0, 1, false)
 
-	return &ClusterWorkloadPanel{flex: flex, content: content}
+	return &ClusterWorkloadPanel{flex: flex, history: h, content: content}
 }
 
 func (p *ClusterWorkloadPanel) Root() tview.Primitive { return p.flex }
 
 func (p *ClusterWorkloadPanel) Update(u process.Update) {
-	views.UpdateClusterStats(p.content.Update)(u)
+	views.UpdateClusterStats(p.history, p.content.Update)(u)
 }
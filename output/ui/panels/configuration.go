@@ -0,0 +1,79 @@
+package panels
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type ConfigurationPanel struct {
+	flex               *tview.Flex
+	summaryContent     *components.StatsGrid[fdb.Configuration]
+	recruitmentContent *components.DataTable[views.RoleRecruitment]
+	regionsContent     *components.DataTable[views.ConfiguredDatacenter]
+	excludedContent    *components.DataTable[fdb.ExcludedServer]
+}
+
+func NewConfiguration() *ConfigurationPanel {
+	summaryContent := components.NewStatsGrid([][]components.ColumnDef[fdb.Configuration]{
+		{views.StatRedundancyMode, views.StatUsableRegions, views.StatStorageWiggle},
+		{views.StatStorageEngine, views.StatCoordinators, views.StatStorageWiggleLocality},
+		{views.StatStorageMigration, views.StatExcludedServers, views.StatEmptyConfiguration},
+	})
+
+	recruitmentContent := components.NewDataTable[views.RoleRecruitment](
+		[]components.ColumnDef[views.RoleRecruitment]{
+			views.ColumnRecruitmentRole, views.ColumnRecruitmentConfigured,
+			views.ColumnRecruitmentRecruited, views.ColumnRecruitmentStatus,
+		})
+
+	regionsContent := components.NewDataTable[views.ConfiguredDatacenter](
+		[]components.ColumnDef[views.ConfiguredDatacenter]{
+			views.ColumnDatacenterRegion, views.ColumnDatacenterId, views.ColumnDatacenterPriority,
+			views.ColumnDatacenterSatellite, views.ColumnDatacenterSatelliteRedundancy, views.ColumnDatacenterSatelliteLogs,
+		})
+
+	excludedContent := components.NewDataTable[fdb.ExcludedServer](
+		[]components.ColumnDef[fdb.ExcludedServer]{
+			views.ColumnExcludedServer, views.ColumnExcludedServerType,
+		})
+
+	tables := tview.NewFlex()
+	tables.AddItem(borderedTable("Role Recruitment", recruitmentContent), 0, 1, false)
+	tables.AddItem(borderedTable("Regions", regionsContent), 0, 2, false)
+	tables.AddItem(borderedTable("Excluded Servers", excludedContent), 0, 1, false)
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(tview.NewTable().SetContent(summaryContent).SetSelectable(false, false), 3, 0, false)
+	flex.AddItem(tables, 0, 1, false)
+
+	return &ConfigurationPanel{
+		flex:               flex,
+		summaryContent:     summaryContent,
+		recruitmentContent: recruitmentContent,
+		regionsContent:     regionsContent,
+		excludedContent:    excludedContent,
+	}
+}
+
+func borderedTable(title string, content tview.TableContent) *tview.Table {
+	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(false, false)
+	table.SetBorder(true)
+	table.SetTitle(title)
+	table.SetTitleColor(tcell.ColorAqua)
+
+	return table
+}
+
+func (p *ConfigurationPanel) Root() tview.Primitive { return p.flex }
+
+func (p *ConfigurationPanel) Update(u process.Update) {
+	views.UpdateConfiguration(p.summaryContent.Update)(u)
+	views.UpdateRoleRecruitment(p.recruitmentContent.Update)(u)
+	views.UpdateConfiguredDatacenters(p.regionsContent.Update)(u)
+	views.UpdateExcludedServers(p.excludedContent.Update)(u)
+}
@@ -0,0 +1,53 @@
+package panels
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/events"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type EventsPanel struct {
+	table    *tview.Table
+	content  *components.DataTable[events.Event]
+	timeline *events.Timeline
+}
+
+func NewEvents(timeline *events.Timeline, store *process.Store, open OpenFn) *EventsPanel {
+	content := components.NewDataTable[events.Event](
+		[]components.ColumnDef[events.Event]{
+			views.ColumnEventTime, views.ColumnEventKind, views.ColumnEventSubject, views.ColumnEventDescription,
+		})
+
+	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyEnter {
+			return event
+		}
+
+		row, _ := table.GetSelection()
+		if row < 1 || row >= content.GetRowCount() {
+			return nil
+		}
+
+		if processes := store.FilterFetch(views.AddressMatch(content.Get(row).Subject)); len(processes) > 0 {
+			open(processes)
+		}
+
+		return nil
+	})
+
+	return &EventsPanel{table: table, content: content, timeline: timeline}
+}
+
+func (p *EventsPanel) Root() tview.Primitive { return p.table }
+
+func (p *EventsPanel) Update(process.Update) {
+	timeline := p.timeline.Events()
+	slices.Reverse(timeline)
+	p.content.Update(timeline)
+}
@@ -0,0 +1,139 @@
+package panels
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+const heatmapCellWidth = 7
+
+type HeatmapPanel struct {
+	flex   *tview.Flex
+	title  *tview.TextView
+	table  *tview.Table
+	info   *tview.TextView
+	store  *process.Store
+	open   OpenFn
+	metric int
+
+	rows []views.HeatmapRow
+}
+
+func NewHeatmap(store *process.Store, open OpenFn) *HeatmapPanel {
+	p := &HeatmapPanel{
+		title: tview.NewTextView().SetDynamicColors(true),
+		table: tview.NewTable().SetSelectable(true, true).SetFixed(0, 1),
+		info:  tview.NewTextView().SetDynamicColors(true),
+		store: store,
+		open:  open,
+	}
+
+	p.table.SetSelectionChangedFunc(func(int, int) { p.describe() })
+	p.table.SetInputCapture(p.input)
+
+	p.flex = tview.NewFlex()
+	p.flex.SetDirection(tview.FlexRow)
+	p.flex.AddItem(p.title, 1, 0, false)
+	p.flex.AddItem(p.table, 0, 1, true)
+	p.flex.AddItem(p.info, 2, 0, false)
+
+	store.AddNotifiable(p.update, views.All)
+
+	return p
+}
+
+func (p *HeatmapPanel) Root() tview.Primitive { return p.flex }
+func (p *HeatmapPanel) Update(process.Update) {}
+
+func (p *HeatmapPanel) update(processes []process.Process) {
+	p.rows = views.BuildHeatmap(processes)
+	p.render()
+}
+
+func (p *HeatmapPanel) render() {
+	metric := views.HeatmapMetrics[p.metric]
+	p.title.SetText(fmt.Sprintf("Metric: [yellow]%s[-]  (m: change metric, h/j/k/l: move, Space: select, Enter: detail)", metric.Name))
+
+	row, column := p.table.GetSelection()
+	p.table.Clear()
+
+	for r, hr := range p.rows {
+		label := fmt.Sprintf("%s / %s", hr.DataCenter, hr.Zone)
+		p.table.SetCell(r, 0, tview.NewTableCell(tview.Escape(label)).SetTextColor(tcell.ColorAqua).SetSelectable(false))
+
+		for c, machine := range hr.Machines {
+			text, severity := metric.ValueFn(machine)
+			colour := views.HeatmapColour(machine, severity)
+
+			cell := tview.NewTableCell(fmt.Sprintf(" %-*s", heatmapCellWidth, text)).
+				SetTextColor(tcell.ColorWhite).
+				SetBackgroundColor(colour).
+				SetReference(machine)
+
+			p.table.SetCell(r, c+1, cell)
+		}
+	}
+
+	if column < 1 {
+		column = 1
+	}
+
+	p.table.Select(row, column)
+	p.describe()
+}
+
+func (p *HeatmapPanel) machine() (views.HeatmapMachine, bool) {
+	row, column := p.table.GetSelection()
+
+	if ref := p.table.GetCell(row, column).GetReference(); ref != nil {
+		return ref.(views.HeatmapMachine), true
+	}
+
+	return views.HeatmapMachine{}, false
+}
+
+func (p *HeatmapPanel) describe() {
+	machine, ok := p.machine()
+	if !ok {
+		p.info.SetText("")
+		return
+	}
+
+	text := fmt.Sprintf("Machine: [yellow]%s[-]  Processes: %d", tview.Escape(machine.Name), len(machine.Processes))
+
+	for _, proc := range machine.Processes {
+		text = fmt.Sprintf("%s  [%s]%s[-]", text, views.ProcessColour(proc).String(), proc.FDBData.Address)
+	}
+
+	p.info.SetText(text)
+}
+
+func (p *HeatmapPanel) input(event *tcell.EventKey) *tcell.EventKey {
+	switch {
+	case event.Key() == tcell.KeyRune && event.Rune() == 'm':
+		p.metric = (p.metric + 1) % len(views.HeatmapMetrics)
+		p.render()
+	case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+		if machine, ok := p.machine(); ok {
+			selected := !views.AllSelected(machine.Processes)
+
+			for _, proc := range machine.Processes {
+				proc.Metadata.Selected = selected
+			}
+
+			p.store.Sort()
+		}
+	case event.Key() == tcell.KeyEnter:
+		if machine, ok := p.machine(); ok {
+			p.open(machine.Processes)
+		}
+	default:
+		return event
+	}
+
+	return nil
+}
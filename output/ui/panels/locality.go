@@ -12,7 +12,7 @@ type LocalityPanel struct {
 	content *components.DataTable[process.Process]
 }
 
-func NewLocality(store *process.Store) *LocalityPanel {
+func NewLocality(store *process.Store, open OpenFn) *LocalityPanel {
 	content := components.NewDataTable[process.Process](
 		[]components.ColumnDef[process.Process]{
 			views.ColumnSelected, views.ColumnIPAddressPort, views.ColumnTLS, views.ColumnStatus,
@@ -23,7 +23,7 @@ func NewLocality(store *process.Store) *LocalityPanel {
 	store.AddNotifiable(content.Update, views.All)
 
 	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
-	table.SetInputCapture(handleNodeSelection(table, content, store))
+	table.SetInputCapture(handleNodeSelection(table, content, store, open))
 
 	return &LocalityPanel{table: table, content: content}
 }
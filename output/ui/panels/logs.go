@@ -12,7 +12,7 @@ type LogsPanel struct {
 	content *components.DataTable[process.Process]
 }
 
-func NewLogs(store *process.Store) *LogsPanel {
+func NewLogs(store *process.Store, open OpenFn) *LogsPanel {
 	content := components.NewDataTable[process.Process](
 		[]components.ColumnDef[process.Process]{
 			views.ColumnSelected, views.ColumnIPAddressPort, views.ColumnCPUActivity,
@@ -23,7 +23,7 @@ func NewLogs(store *process.Store) *LogsPanel {
 	store.AddNotifiable(content.Update, views.RoleMatch("log"))
 
 	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
-	table.SetInputCapture(handleNodeSelection(table, content, store))
+	table.SetInputCapture(handleNodeSelection(table, content, store, open))
 
 	return &LogsPanel{table: table, content: content}
 }
@@ -0,0 +1,108 @@
+package panels
+
+import (
+	"fmt"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/messages"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type MessagesPanel struct {
+	flex    *tview.Flex
+	title   *tview.TextView
+	content *components.DataTable[messages.Entry]
+	tracker *messages.Tracker
+	filter  string
+}
+
+func NewMessages(tracker *messages.Tracker, store *process.Store, open OpenFn) *MessagesPanel {
+	p := &MessagesPanel{
+		title:   tview.NewTextView().SetDynamicColors(true),
+		tracker: tracker,
+		content: components.NewDataTable[messages.Entry](
+			[]components.ColumnDef[messages.Entry]{
+				views.ColumnMessageSource, views.ColumnMessageClass, views.ColumnMessageName,
+				views.ColumnMessageDescription, views.ColumnMessageFirstSeen, views.ColumnMessageLastSeen,
+				views.ColumnMessageActive,
+			}),
+	}
+
+	table := tview.NewTable().SetContent(p.content).SetFixed(1, 0).SetSelectable(true, false)
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() == tcell.KeyRune && event.Rune() == 'n' {
+			p.nextFilter()
+			return nil
+		}
+
+		if event.Key() != tcell.KeyEnter {
+			return event
+		}
+
+		row, _ := table.GetSelection()
+		if row < 1 || row >= p.content.GetRowCount() {
+			return nil
+		}
+
+		if processes := store.FilterFetch(views.AddressMatch(p.content.Get(row).Source)); len(processes) > 0 {
+			open(processes)
+		}
+
+		return nil
+	})
+
+	p.flex = tview.NewFlex()
+	p.flex.SetDirection(tview.FlexRow)
+	p.flex.AddItem(p.title, 1, 0, false)
+	p.flex.AddItem(table, 0, 1, true)
+
+	p.render()
+
+	return p
+}
+
+func (p *MessagesPanel) Root() tview.Primitive { return p.flex }
+
+func (p *MessagesPanel) Update(process.Update) {
+	p.render()
+}
+
+func (p *MessagesPanel) nextFilter() {
+	names := p.tracker.Names()
+
+	next := ""
+	for i, name := range names {
+		if name == p.filter && i+1 < len(names) {
+			next = names[i+1]
+			break
+		}
+	}
+
+	if p.filter == "" && len(names) > 0 {
+		next = names[0]
+	}
+
+	p.filter = next
+	p.render()
+}
+
+func (p *MessagesPanel) render() {
+	filter := "All"
+	if p.filter != "" {
+		filter = p.filter
+	}
+
+	p.title.SetText(fmt.Sprintf("Name: [yellow]%s[-]  (n: next name, Enter: process detail)", tview.Escape(filter)))
+
+	var entries []messages.Entry
+	for _, e := range p.tracker.Entries() {
+		if views.MessageNameMatch(p.filter)(e) {
+			entries = append(entries, e)
+		}
+	}
+
+	p.content.Update(entries)
+}
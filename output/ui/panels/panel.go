@@ -12,14 +12,27 @@ type Panel interface {
 	Update(process.Update)
 }
 
-func handleNodeSelection(table *tview.Table, content *components.DataTable[process.Process], store *process.Store) func(*tcell.EventKey) *tcell.EventKey {
+type OpenFn func([]process.Process)
+
+func handleNodeSelection(table *tview.Table, content *components.DataTable[process.Process], store *process.Store, open OpenFn) func(*tcell.EventKey) *tcell.EventKey {
 	return func(event *tcell.EventKey) *tcell.EventKey {
+		if content.GetRowCount() <= 1 {
+			return event
+		}
+
 		if event.Key() == tcell.KeyRune && event.Rune() == ' ' {
 			row, _ := table.GetSelection()
 			content.Get(row).Metadata.ToggleSelected()
 			store.Sort()
 			return nil
 		}
+
+		if event.Key() == tcell.KeyEnter {
+			row, _ := table.GetSelection()
+			open([]process.Process{*content.Get(row)})
+			return nil
+		}
+
 		return event
 	}
 }
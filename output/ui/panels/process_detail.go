@@ -0,0 +1,134 @@
+package panels
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+const latencyHistogramWidth = 30
+
+type ProcessDetailPanel struct {
+	flex    *tview.Flex
+	info    *tview.TextView
+	latency *tview.TextView
+	charts  []*components.Chart
+	store   *process.Store
+	history *history.History
+
+	addresses []string
+}
+
+func NewProcessDetail(store *process.Store, h *history.History) *ProcessDetailPanel {
+	info := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+	latency := tview.NewTextView().SetDynamicColors(true).SetWrap(false)
+
+	grid := tview.NewGrid().SetRows(0, 0, 0).SetColumns(0, 0)
+
+	var charts []*components.Chart
+
+	for i, pc := range views.ProcessCharts {
+		chart := components.NewChart(pc.Title, pc.FormatFn)
+		charts = append(charts, chart)
+		grid.AddItem(chart, i/2, i%2, 1, 1, 0, 0, false)
+	}
+
+	top := tview.NewFlex()
+	top.AddItem(info, 0, 1, false)
+	top.AddItem(latency, 0, 1, false)
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(top, 8, 0, false)
+	flex.AddItem(grid, 0, 1, true)
+
+	return &ProcessDetailPanel{flex: flex, info: info, latency: latency, charts: charts, store: store, history: h}
+}
+
+func (p *ProcessDetailPanel) Root() tview.Primitive { return p.flex }
+
+func (p *ProcessDetailPanel) Show(processes []process.Process) {
+	wanted := make(map[string]struct{})
+
+	for _, proc := range processes {
+		wanted[proc.FDBData.Address] = struct{}{}
+	}
+
+	for _, proc := range p.store.FilterFetch(views.Selected) {
+		wanted[proc.FDBData.Address] = struct{}{}
+	}
+
+	p.addresses = nil
+
+	for _, proc := range p.store.FilterFetch(views.All) {
+		if _, ok := wanted[proc.FDBData.Address]; ok {
+			p.addresses = append(p.addresses, proc.FDBData.Address)
+		}
+	}
+
+	p.refresh()
+}
+
+func (p *ProcessDetailPanel) Update(process.Update) {
+	p.refresh()
+}
+
+func (p *ProcessDetailPanel) processes() []process.Process {
+	byAddress := make(map[string]process.Process)
+
+	for _, proc := range p.store.FilterFetch(views.All) {
+		byAddress[proc.FDBData.Address] = proc
+	}
+
+	var processes []process.Process
+
+	for _, addr := range p.addresses {
+		if proc, ok := byAddress[addr]; ok {
+			processes = append(processes, proc)
+		}
+	}
+
+	return processes
+}
+
+func (p *ProcessDetailPanel) refresh() {
+	processes := p.processes()
+
+	var lines []string
+
+	for i, proc := range processes {
+		lines = append(lines, fmt.Sprintf("[%s]■[-] %s  %s  %s  %s  %s  %s",
+			views.SeriesColour(i).String(),
+			views.ColumnIPAddressPort.Data(proc), views.ColumnClass.Data(proc), views.ColumnRoles.Data(proc),
+			views.ColumnVersion.Data(proc), views.ColumnUptime.Data(proc), views.ColumnStatus.Data(proc)))
+	}
+
+	p.info.SetText(strings.Join(lines, "\n"))
+
+	var histograms []string
+
+	for i, proc := range processes {
+		histograms = append(histograms, views.LatencyHistogram(proc, views.SeriesColour(i).String(), latencyHistogramWidth)...)
+	}
+
+	p.latency.SetText(strings.Join(histograms, "\n"))
+
+	for ci, pc := range views.ProcessCharts {
+		var series []components.ChartSeries
+
+		for i, proc := range processes {
+			series = append(series, components.ChartSeries{
+				Name:   proc.FDBData.Address,
+				Color:  views.SeriesColour(i),
+				Values: history.Series(p.history.Process(proc.FDBData.Address), pc.SampleFn),
+			})
+		}
+
+		p.charts[ci].Update(series)
+	}
+}
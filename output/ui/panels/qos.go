@@ -0,0 +1,63 @@
+package panels
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type QoSPanel struct {
+	flex             *tview.Flex
+	summaryContent   *components.StatsGrid[views.QoS]
+	offendersContent *components.DataTable[views.QoSOffender]
+}
+
+func NewQoS(store *process.Store, open OpenFn) *QoSPanel {
+	summaryContent := components.NewStatsGrid([][]components.ColumnDef[views.QoS]{
+		{views.StatLimitedBy, views.StatBatchLimitedBy},
+		{views.StatLimitedByDescription, views.StatBatchLimitedByDescription},
+		{views.StatTPSLimit, views.StatBatchTPSLimit},
+		{views.StatAutoThrottledTags, views.StatManualThrottledTags},
+	})
+
+	offendersContent := components.NewDataTable[views.QoSOffender](
+		[]components.ColumnDef[views.QoSOffender]{
+			views.ColumnQoSOffenderMetric, views.ColumnQoSOffenderValue, views.ColumnQoSOffenderProcess,
+		})
+
+	offenders := tview.NewTable().SetContent(offendersContent).SetFixed(1, 0).SetSelectable(true, false)
+	offenders.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyEnter {
+			return event
+		}
+
+		row, _ := offenders.GetSelection()
+		if row < 1 || row >= offendersContent.GetRowCount() {
+			return nil
+		}
+
+		address := offendersContent.Get(row).Address
+		if processes := store.FilterFetch(views.AddressMatch(address)); len(processes) > 0 {
+			open(processes)
+		}
+
+		return nil
+	})
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(tview.NewTable().SetContent(summaryContent).SetSelectable(false, false), 4, 0, false)
+	flex.AddItem(tview.NewBox(), 1, 0, false)
+	flex.AddItem(offenders, 0, 1, true)
+
+	return &QoSPanel{flex: flex, summaryContent: summaryContent, offendersContent: offendersContent}
+}
+
+func (p *QoSPanel) Root() tview.Primitive { return p.flex }
+
+func (p *QoSPanel) Update(u process.Update) {
+	views.UpdateQoS(p.summaryContent.Update)(u)
+	views.UpdateQoSOffenders(p.offendersContent.Update)(u)
+}
@@ -0,0 +1,54 @@
+package panels
+
+import (
+	"slices"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/data/recovery"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type RecoveriesPanel struct {
+	table   *tview.Table
+	content *components.DataTable[recovery.Recovery]
+	tracker *recovery.Tracker
+}
+
+func NewRecoveries(tracker *recovery.Tracker, store *process.Store, open OpenFn) *RecoveriesPanel {
+	content := components.NewDataTable[recovery.Recovery](
+		[]components.ColumnDef[recovery.Recovery]{
+			views.ColumnRecoveryStarted, views.ColumnRecoveryEnded, views.ColumnRecoveryDuration,
+			views.ColumnRecoveryGeneration, views.ColumnRecoveryMaster, views.ColumnRecoveryStages,
+		})
+
+	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if event.Key() != tcell.KeyEnter {
+			return event
+		}
+
+		row, _ := table.GetSelection()
+		if row < 1 || row >= content.GetRowCount() {
+			return nil
+		}
+
+		if processes := store.FilterFetch(views.AddressMatch(content.Get(row).Master)); len(processes) > 0 {
+			open(processes)
+		}
+
+		return nil
+	})
+
+	return &RecoveriesPanel{table: table, content: content, tracker: tracker}
+}
+
+func (p *RecoveriesPanel) Root() tview.Primitive { return p.table }
+
+func (p *RecoveriesPanel) Update(process.Update) {
+	recoveries := p.tracker.Recoveries()
+	slices.Reverse(recoveries)
+	p.content.Update(recoveries)
+}
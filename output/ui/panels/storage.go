@@ -12,19 +12,19 @@ type StoragePanel struct {
 	content *components.DataTable[process.Process]
 }
 
-func NewStorage(store *process.Store) *StoragePanel {
+func NewStorage(store *process.Store, open OpenFn) *StoragePanel {
 	content := components.NewDataTable[process.Process](
 		[]components.ColumnDef[process.Process]{
 			views.ColumnSelected, views.ColumnIPAddressPort, views.ColumnCPUActivity,
 			views.ColumnRAMUsage, views.ColumnDiskUsage, views.ColumnDiskActivity,
 			views.ColumnKVStorage, views.ColumnStorageDurabilityRate,
-			views.ColumnStorageLag, views.ColumnStorageTotalQueries,
+			views.ColumnStorageLag, views.ColumnStorageTotalQueries, views.ColumnStorageReadLatency,
 		})
 
 	store.AddNotifiable(content.Update, views.RoleMatch("storage"))
 
 	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
-	table.SetInputCapture(handleNodeSelection(table, content, store))
+	table.SetInputCapture(handleNodeSelection(table, content, store, open))
 
 	return &StoragePanel{table: table, content: content}
 }
@@ -0,0 +1,104 @@
+package panels
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type TopologyPanel struct {
+	tree     *tview.TreeView
+	store    *process.Store
+	open     OpenFn
+	expanded map[string]bool
+}
+
+func NewTopology(store *process.Store, open OpenFn) *TopologyPanel {
+	p := &TopologyPanel{
+		tree:     tview.NewTreeView(),
+		store:    store,
+		open:     open,
+		expanded: map[string]bool{"": true},
+	}
+
+	p.tree.SetGraphicsColor(tcell.ColorGray)
+	p.tree.SetSelectedFunc(p.selected)
+	p.tree.SetInputCapture(p.input)
+
+	store.AddNotifiable(p.update, views.All)
+
+	return p
+}
+
+func (p *TopologyPanel) Root() tview.Primitive { return p.tree }
+func (p *TopologyPanel) Update(process.Update) {}
+
+func (p *TopologyPanel) update(processes []process.Process) {
+	currentKey := ""
+	if current := p.tree.GetCurrentNode(); current != nil {
+		currentKey = current.GetReference().(*views.TopologyNode).Key
+	}
+
+	var current *tview.TreeNode
+
+	root := p.node(views.BuildTopology(processes), currentKey, &current)
+	p.tree.SetRoot(root)
+
+	if current == nil {
+		current = root
+	}
+
+	p.tree.SetCurrentNode(current)
+}
+
+func (p *TopologyPanel) node(tn *views.TopologyNode, currentKey string, current **tview.TreeNode) *tview.TreeNode {
+	node := tview.NewTreeNode(tview.Escape(tn.Label())).
+		SetReference(tn).
+		SetColor(tn.Colour()).
+		SetExpanded(p.expanded[tn.Key])
+
+	if tn.Key == currentKey {
+		*current = node
+	}
+
+	for _, child := range tn.Children {
+		node.AddChild(p.node(child, currentKey, current))
+	}
+
+	return node
+}
+
+func (p *TopologyPanel) selected(node *tview.TreeNode) {
+	tn := node.GetReference().(*views.TopologyNode)
+
+	if tn.Level == views.TopologyProcess {
+		p.open(tn.Processes)
+		return
+	}
+
+	node.SetExpanded(!node.IsExpanded())
+	p.expanded[tn.Key] = node.IsExpanded()
+}
+
+func (p *TopologyPanel) input(event *tcell.EventKey) *tcell.EventKey {
+	if event.Key() != tcell.KeyRune || event.Rune() != ' ' {
+		return event
+	}
+
+	current := p.tree.GetCurrentNode()
+	if current == nil {
+		return nil
+	}
+
+	tn := current.GetReference().(*views.TopologyNode)
+	selected := !tn.AllSelected()
+
+	for _, proc := range tn.Processes {
+		proc.Metadata.Selected = selected
+	}
+
+	p.store.Sort()
+
+	return nil
+}
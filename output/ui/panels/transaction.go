@@ -0,0 +1,52 @@
+package panels
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type TransactionPanel struct {
+	table   *tview.Table
+	content *components.DataTable[views.TransactionRole]
+}
+
+func NewTransaction(store *process.Store, open OpenFn) *TransactionPanel {
+	content := components.NewDataTable[views.TransactionRole](
+		[]components.ColumnDef[views.TransactionRole]{
+			views.ColumnTransactionRole, views.ColumnTransactionAddress, views.ColumnTransactionClass,
+			views.TransactionProcessColumn(views.ColumnUptime), views.ColumnTransactionShared, views.ColumnTransactionLatency, views.ColumnTransactionMetrics,
+		})
+
+	store.AddNotifiable(func(processes []process.Process) {
+		content.Update(views.BuildTransactionRoles(processes))
+	}, views.StatelessRoleMatch)
+
+	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
+	table.SetInputCapture(func(event *tcell.EventKey) *tcell.EventKey {
+		if content.GetRowCount() <= 1 {
+			return event
+		}
+
+		row, _ := table.GetSelection()
+
+		switch {
+		case event.Key() == tcell.KeyRune && event.Rune() == ' ':
+			content.Get(row).Process.Metadata.ToggleSelected()
+			store.Sort()
+		case event.Key() == tcell.KeyEnter:
+			open([]process.Process{content.Get(row).Process})
+		default:
+			return event
+		}
+
+		return nil
+	})
+
+	return &TransactionPanel{table: table, content: content}
+}
+
+func (p *TransactionPanel) Root() tview.Primitive { return p.table }
+func (p *TransactionPanel) Update(process.Update) {}
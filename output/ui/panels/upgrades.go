@@ -0,0 +1,53 @@
+package panels
+
+import (
+	"github.com/pwood/fdbexplorer/data/upgrade"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/views"
+	"github.com/rivo/tview"
+)
+
+type UpgradesPanel struct {
+	flex               *tview.Flex
+	summaryContent     *components.StatsGrid[upgrade.Report]
+	serversContent     *components.DataTable[upgrade.ServerVersion]
+	unsupportedContent *components.DataTable[upgrade.Client]
+}
+
+func NewUpgrades() *UpgradesPanel {
+	summaryContent := components.NewStatsGrid([][]components.ColumnDef[upgrade.Report]{
+		{views.StatUpgradeTarget, views.StatUpgradeServerVersions, views.StatUpgradeClients},
+		{views.StatUpgradeReady, views.StatUpgradeMixedVersions, views.StatUpgradeMultiVersionClients},
+	})
+
+	serversContent := components.NewDataTable[upgrade.ServerVersion](
+		[]components.ColumnDef[upgrade.ServerVersion]{
+			views.ColumnServerVersion, views.ColumnServerVersionProcesses,
+			views.ColumnServerVersionOnTarget, views.ColumnServerVersionAddresses,
+		})
+
+	unsupportedContent := components.NewDataTable[upgrade.Client](
+		[]components.ColumnDef[upgrade.Client]{
+			views.ColumnUpgradeClientAddress, views.ColumnUpgradeClientLogGroup, views.ColumnUpgradeClientVersions,
+		})
+
+	flex := tview.NewFlex()
+	flex.SetDirection(tview.FlexRow)
+	flex.AddItem(tview.NewTable().SetContent(summaryContent).SetSelectable(false, false), 2, 0, false)
+	flex.AddItem(borderedTable("Server Versions", serversContent), 0, 1, false)
+	flex.AddItem(borderedTable("Clients Without Target Protocol Support", unsupportedContent), 0, 1, false)
+
+	return &UpgradesPanel{
+		flex:               flex,
+		summaryContent:     summaryContent,
+		serversContent:     serversContent,
+		unsupportedContent: unsupportedContent,
+	}
+}
+
+func (p *UpgradesPanel) Root() tview.Primitive { return p.flex }
+
+func (p *UpgradesPanel) Update(u process.Update) {
+	views.UpdateUpgradeReport(p.summaryContent.Update, p.serversContent.Update, p.unsupportedContent.Update)(u)
+}
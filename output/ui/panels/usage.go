@@ -12,7 +12,7 @@ type UsagePanel struct {
 	content *components.DataTable[process.Process]
 }
 
-func NewUsage(store *process.Store) *UsagePanel {
+func NewUsage(store *process.Store, open OpenFn) *UsagePanel {
 	content := components.NewDataTable[process.Process](
 		[]components.ColumnDef[process.Process]{
 			views.ColumnSelected, views.ColumnIPAddressPort, views.ColumnRoles,
@@ -23,7 +23,7 @@ func NewUsage(store *process.Store) *UsagePanel {
 	store.AddNotifiable(content.Update, views.All)
 
 	table := tview.NewTable().SetContent(content).SetFixed(1, 0).SetSelectable(true, false)
-	table.SetInputCapture(handleNodeSelection(table, content, store))
+	table.SetInputCapture(handleNodeSelection(table, content, store, open))
 
 	return &UsagePanel{table: table, content: content}
 }
@@ -0,0 +1,87 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/alerts"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+func AlertColour(a alerts.Alert) tcell.Color {
+	if a.Rule.Severity == alerts.SeverityCritical {
+		return tcell.ColorRed
+	}
+	return tcell.ColorYellow
+}
+
+func AlertValue(metric string, v float64) string {
+	switch {
+	case strings.HasSuffix(metric, "_bytes"):
+		return Convert(v, 1, None)
+	case strings.HasSuffix(metric, "_percent"):
+		return fmt.Sprintf("%0.1f%%", v)
+	case strings.HasSuffix(metric, "_seconds"):
+		return fmt.Sprintf("%0.3fs", v)
+	default:
+		return fmt.Sprintf("%g", v)
+	}
+}
+
+var ColumnAlertSeverity = components.ColumnImpl[alerts.Alert]{
+	ColName: "Severity",
+	DataFn: func(a alerts.Alert) string {
+		return Titlify(string(a.Rule.Severity))
+	},
+	ColorFn: AlertColour,
+}
+
+var ColumnAlertRule = components.ColumnImpl[alerts.Alert]{
+	ColName: "Rule",
+	DataFn: func(a alerts.Alert) string {
+		return a.Rule.Name
+	},
+	ColorFn: AlertColour,
+}
+
+var ColumnAlertSubject = components.ColumnImpl[alerts.Alert]{
+	ColName: "Subject",
+	DataFn: func(a alerts.Alert) string {
+		if a.Subject == process.ClusterSubject {
+			return "Cluster"
+		}
+		return a.Subject
+	},
+	ColorFn: AlertColour,
+}
+
+var ColumnAlertValue = components.ColumnImpl[alerts.Alert]{
+	ColName: "Value",
+	DataFn: func(a alerts.Alert) string {
+		return AlertValue(a.Rule.Metric, a.Value)
+	},
+	ColorFn: AlertColour,
+}
+
+var ColumnAlertCondition = components.ColumnImpl[alerts.Alert]{
+	ColName: "Condition",
+	DataFn: func(a alerts.Alert) string {
+		condition := fmt.Sprintf("%s %s %s", a.Rule.Metric, a.Rule.Operator, AlertValue(a.Rule.Metric, float64(a.Rule.Value)))
+		if a.Rule.For > 0 {
+			condition = fmt.Sprintf("%s for %s", condition, time.Duration(a.Rule.For))
+		}
+		return condition
+	},
+	ColorFn: AlertColour,
+}
+
+var ColumnAlertSince = components.ColumnImpl[alerts.Alert]{
+	ColName: "Since",
+	DataFn: func(a alerts.Alert) string {
+		return fmt.Sprintf("%s (%s)", a.Since.Format(messageTimeFormat), Durationify(time.Since(a.Since)))
+	},
+	ColorFn: AlertColour,
+}
@@ -10,19 +10,23 @@ import (
 	"time"
 )
 
-func UpdateBackupInstances(f func(instance []fdb.BackupInstance)) func(process.Update) {
-	return func(dsu process.Update) {
-		var instances []fdb.BackupInstance
+func BackupInstancesOf(root fdb.Root) []fdb.BackupInstance {
+	var instances []fdb.BackupInstance
+
+	for _, instance := range root.Cluster.Layers.Backup.Instances {
+		instances = append(instances, instance)
+	}
 
-		for _, instance := range dsu.Root.Cluster.Layers.Backup.Instances {
-			instances = append(instances, instance)
-		}
+	sort.Slice(instances, func(i, j int) bool {
+		return strings.Compare(instances[i].Id, instances[j].Id) < 0
+	})
 
-		sort.Slice(instances, func(i, j int) bool {
-			return strings.Compare(instances[i].Id, instances[j].Id) < 0
-		})
+	return instances
+}
 
-		f(instances)
+func UpdateBackupInstances(f func(instance []fdb.BackupInstance)) func(process.Update) {
+	return func(dsu process.Update) {
+		f(BackupInstancesOf(dsu.Root))
 	}
 }
 
@@ -68,20 +72,24 @@ var ColumnBackupInstanceRecentOperations = components.ColumnImpl[fdb.BackupInsta
 	},
 }
 
-func UpdateBackupTags(f func(instance []fdb.BackupTag)) func(process.Update) {
-	return func(dsu process.Update) {
-		var tags []fdb.BackupTag
+func BackupTagsOf(root fdb.Root) []fdb.BackupTag {
+	var tags []fdb.BackupTag
 
-		for id, tag := range dsu.Root.Cluster.Layers.Backup.Tags {
-			tag.Id = id
-			tags = append(tags, tag)
-		}
+	for id, tag := range root.Cluster.Layers.Backup.Tags {
+		tag.Id = id
+		tags = append(tags, tag)
+	}
 
-		sort.Slice(tags, func(i, j int) bool {
-			return strings.Compare(tags[i].Id, tags[j].Id) < 0
-		})
+	sort.Slice(tags, func(i, j int) bool {
+		return strings.Compare(tags[i].Id, tags[j].Id) < 0
+	})
 
-		f(tags)
+	return tags
+}
+
+func UpdateBackupTags(f func(instance []fdb.BackupTag)) func(process.Update) {
+	return func(dsu process.Update) {
+		f(BackupTagsOf(dsu.Root))
 	}
 }
 
@@ -141,35 +149,43 @@ var ColumnBackupLogBytes = components.ColumnImpl[fdb.BackupTag]{
 	},
 }
 
-func UpdateDrBackupInstances(f func(instance []fdb.DRBackupInstance)) func(process.Update) {
-	return func(dsu process.Update) {
-		var instances []fdb.DRBackupInstance
+func DrBackupInstancesOf(root fdb.Root) []fdb.DRBackupInstance {
+	var instances []fdb.DRBackupInstance
 
-		for _, instance := range dsu.Root.Cluster.Layers.DRBackup.Instances {
-			instances = append(instances, instance)
-		}
+	for _, instance := range root.Cluster.Layers.DRBackup.Instances {
+		instances = append(instances, instance)
+	}
 
-		sort.Slice(instances, func(i, j int) bool {
-			return strings.Compare(instances[i].Id, instances[j].Id) < 0
-		})
+	sort.Slice(instances, func(i, j int) bool {
+		return strings.Compare(instances[i].Id, instances[j].Id) < 0
+	})
 
-		f(instances)
-	}
+	return instances
 }
 
-func UpdateDrBackupDestInstances(f func(instance []fdb.DRBackupInstance)) func(process.Update) {
+func UpdateDrBackupInstances(f func(instance []fdb.DRBackupInstance)) func(process.Update) {
 	return func(dsu process.Update) {
-		var instances []fdb.DRBackupInstance
+		f(DrBackupInstancesOf(dsu.Root))
+	}
+}
+
+func DrBackupDestInstancesOf(root fdb.Root) []fdb.DRBackupInstance {
+	var instances []fdb.DRBackupInstance
+
+	for _, instance := range root.Cluster.Layers.DRBackupDest.Instances {
+		instances = append(instances, instance)
+	}
 
-		for _, instance := range dsu.Root.Cluster.Layers.DRBackupDest.Instances {
-			instances = append(instances, instance)
-		}
+	sort.Slice(instances, func(i, j int) bool {
+		return strings.Compare(instances[i].Id, instances[j].Id) < 0
+	})
 
-		sort.Slice(instances, func(i, j int) bool {
-			return strings.Compare(instances[i].Id, instances[j].Id) < 0
-		})
+	return instances
+}
 
-		f(instances)
+func UpdateDrBackupDestInstances(f func(instance []fdb.DRBackupInstance)) func(process.Update) {
+	return func(dsu process.Update) {
+		f(DrBackupDestInstancesOf(dsu.Root))
 	}
 }
 
@@ -222,37 +238,45 @@ var ColumnDRBackupInstanceProcessCPU = components.ColumnImpl[fdb.DRBackupInstanc
 	},
 }
 
-func UpdateDrBackupTags(f func(instance []fdb.DRBackupTag)) func(process.Update) {
-	return func(dsu process.Update) {
-		var instances []fdb.DRBackupTag
+func DrBackupTagsOf(root fdb.Root) []fdb.DRBackupTag {
+	var instances []fdb.DRBackupTag
 
-		for tag, instance := range dsu.Root.Cluster.Layers.DRBackup.Tags {
-			instance.Id = tag
-			instances = append(instances, instance)
-		}
+	for tag, instance := range root.Cluster.Layers.DRBackup.Tags {
+		instance.Id = tag
+		instances = append(instances, instance)
+	}
 
-		sort.Slice(instances, func(i, j int) bool {
-			return strings.Compare(instances[i].Id, instances[j].Id) < 0
-		})
+	sort.Slice(instances, func(i, j int) bool {
+		return strings.Compare(instances[i].Id, instances[j].Id) < 0
+	})
 
-		f(instances)
-	}
+	return instances
 }
 
-func UpdateDrBackupDestTags(f func(instance []fdb.DRBackupTag)) func(process.Update) {
+func UpdateDrBackupTags(f func(instance []fdb.DRBackupTag)) func(process.Update) {
 	return func(dsu process.Update) {
-		var instances []fdb.DRBackupTag
+		f(DrBackupTagsOf(dsu.Root))
+	}
+}
+
+func DrBackupDestTagsOf(root fdb.Root) []fdb.DRBackupTag {
+	var instances []fdb.DRBackupTag
 
-		for tag, instance := range dsu.Root.Cluster.Layers.DRBackupDest.Tags {
-			instance.Id = tag
-			instances = append(instances, instance)
-		}
+	for tag, instance := range root.Cluster.Layers.DRBackupDest.Tags {
+		instance.Id = tag
+		instances = append(instances, instance)
+	}
+
+	sort.Slice(instances, func(i, j int) bool {
+		return strings.Compare(instances[i].Id, instances[j].Id) < 0
+	})
 
-		sort.Slice(instances, func(i, j int) bool {
-			return strings.Compare(instances[i].Id, instances[j].Id) < 0
-		})
+	return instances
+}
 
-		f(instances)
+func UpdateDrBackupDestTags(f func(instance []fdb.DRBackupTag)) func(process.Update) {
+	return func(dsu process.Update) {
+		f(DrBackupDestTagsOf(dsu.Root))
 	}
 }
 
@@ -0,0 +1,208 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/data/upgrade"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"sort"
+	"strings"
+)
+
+type Client struct {
+	fdb.ConnectedClient
+	ClientVersion   string
+	ProtocolVersion string
+	SupportsTarget  bool
+}
+
+type ClientVersionGroup struct {
+	fdb.SupportedVersions
+	SupportsTarget bool
+}
+
+func clientsSupportingTarget(versions []fdb.SupportedVersions, target string) map[string]bool {
+	supported := make(map[string]bool)
+
+	for _, sv := range versions {
+		for _, c := range sv.ConnectedClients {
+			if upgrade.Compatible(sv.ProtocolVersion, target) {
+				supported[c.Address] = true
+			}
+		}
+	}
+
+	return supported
+}
+
+func sortedVersions(versions []fdb.SupportedVersions) []fdb.SupportedVersions {
+	sorted := append([]fdb.SupportedVersions(nil), versions...)
+
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].ProtocolVersion != sorted[j].ProtocolVersion {
+			return sorted[i].ProtocolVersion < sorted[j].ProtocolVersion
+		}
+		return sorted[i].ClientVersion < sorted[j].ClientVersion
+	})
+
+	return sorted
+}
+
+func UpdateClientVersions(f func([]ClientVersionGroup)) func(process.Update) {
+	return func(dsu process.Update) {
+		target := upgrade.TargetVersion()
+
+		var groups []ClientVersionGroup
+
+		for _, sv := range sortedVersions(dsu.Root.Cluster.Clients.SupportedVersions) {
+			groups = append(groups, ClientVersionGroup{
+				SupportedVersions: sv,
+				SupportsTarget:    target == "" || upgrade.Compatible(sv.ProtocolVersion, target),
+			})
+		}
+
+		f(groups)
+	}
+}
+
+func UpdateClients(f func([]Client)) func(process.Update) {
+	return func(dsu process.Update) {
+		target := upgrade.TargetVersion()
+		versions := dsu.Root.Cluster.Clients.SupportedVersions
+		supported := clientsSupportingTarget(versions, target)
+
+		var clients []Client
+
+		for _, sv := range sortedVersions(versions) {
+			connected := append([]fdb.ConnectedClient(nil), sv.ConnectedClients...)
+
+			sort.Slice(connected, func(i, j int) bool {
+				return strings.Compare(connected[i].Address, connected[j].Address) < 0
+			})
+
+			for _, c := range connected {
+				clients = append(clients, Client{
+					ConnectedClient: c,
+					ClientVersion:   sv.ClientVersion,
+					ProtocolVersion: sv.ProtocolVersion,
+					SupportsTarget:  target == "" || supported[c.Address],
+				})
+			}
+		}
+
+		f(clients)
+	}
+}
+
+func clientColour(c Client) tcell.Color {
+	if !c.SupportsTarget {
+		return tcell.ColorRed
+	}
+	return tcell.ColorWhite
+}
+
+func clientVersionColour(g ClientVersionGroup) tcell.Color {
+	if !g.SupportsTarget {
+		return tcell.ColorYellow
+	}
+	return tcell.ColorWhite
+}
+
+var ColumnClientVersionGroupVersion = components.ColumnImpl[ClientVersionGroup]{
+	ColName: "Client Version",
+	DataFn: func(g ClientVersionGroup) string {
+		return g.ClientVersion
+	},
+	ColorFn: clientVersionColour,
+}
+
+var ColumnClientVersionGroupProtocol = components.ColumnImpl[ClientVersionGroup]{
+	ColName: "Protocol",
+	DataFn: func(g ClientVersionGroup) string {
+		return g.ProtocolVersion
+	},
+	ColorFn: clientVersionColour,
+}
+
+var ColumnClientVersionGroupCount = components.ColumnImpl[ClientVersionGroup]{
+	ColName: "Clients",
+	DataFn: func(g ClientVersionGroup) string {
+		return fmt.Sprintf("%d", g.Count)
+	},
+	ColorFn: clientVersionColour,
+}
+
+var ColumnClientVersionGroupMaxProtocol = components.ColumnImpl[ClientVersionGroup]{
+	ColName: "Max Protocol Clients",
+	DataFn: func(g ClientVersionGroup) string {
+		return fmt.Sprintf("%d", g.MaxProtocolCount)
+	},
+	ColorFn: clientVersionColour,
+}
+
+var ColumnClientVersionGroupTarget = components.ColumnImpl[ClientVersionGroup]{
+	ColName: "Target Protocol?",
+	DataFn: func(g ClientVersionGroup) string {
+		if upgrade.TargetVersion() == "" {
+			return "-"
+		}
+		return Boolify(g.SupportsTarget)
+	},
+	ColorFn: clientVersionColour,
+}
+
+var ColumnClientAddress = components.ColumnImpl[Client]{
+	ColName: "Address",
+	DataFn: func(c Client) string {
+		return c.Address
+	},
+	ColorFn: clientColour,
+}
+
+var ColumnClientTLS = components.ColumnImpl[Client]{
+	ColName: "TLS",
+	DataFn: func(c Client) string {
+		if c.TLS {
+			return "✓"
+		}
+		return ""
+	},
+	ColorFn: clientColour,
+}
+
+var ColumnClientLogGroup = components.ColumnImpl[Client]{
+	ColName: "Log Group",
+	DataFn: func(c Client) string {
+		return c.LogGroup
+	},
+	ColorFn: clientColour,
+}
+
+var ColumnClientVersion = components.ColumnImpl[Client]{
+	ColName: "Client Version",
+	DataFn: func(c Client) string {
+		return c.ClientVersion
+	},
+	ColorFn: clientColour,
+}
+
+var ColumnClientProtocol = components.ColumnImpl[Client]{
+	ColName: "Protocol",
+	DataFn: func(c Client) string {
+		return c.ProtocolVersion
+	},
+	ColorFn: clientColour,
+}
+
+var ColumnClientTarget = components.ColumnImpl[Client]{
+	ColName: "Supports Target?",
+	DataFn: func(c Client) string {
+		if upgrade.TargetVersion() == "" {
+			return "-"
+		}
+		return Boolify(c.SupportsTarget)
+	},
+	ColorFn: clientColour,
+}
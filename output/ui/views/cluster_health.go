@@ -3,8 +3,12 @@ package views
 import (
 	"fmt"
 	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
 	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
 	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"github.com/pwood/fdbexplorer/output/ui/data/recovery"
+	"time"
 )
 
 type ClusterHealth struct {
@@ -19,20 +23,37 @@ type ClusterHealth struct {
 	RecoveryDescription string
 
 	DatabaseLocked bool
+
+	LatencyProbe   fdb.LatencyProbe
+	FaultTolerance fdb.FaultTolerance
+	RedundancyMode string
+
+	History    []history.ClusterSample
+	Recoveries RecoverySummary
+}
+
+func ClusterHealthOf(root fdb.Root) ClusterHealth {
+	return ClusterHealth{
+		Healthy:             root.Cluster.Data.State.Health,
+		Health:              Titlify(root.Cluster.Data.State.Name),
+		MinReplicas:         root.Cluster.Data.State.MinReplicasRemaining,
+		RebalanceQueued:     root.Cluster.Data.MovingData.InQueueBytes,
+		RebalanceInFlight:   root.Cluster.Data.MovingData.InFlightBytes,
+		RecoveryState:       Titlify(root.Cluster.RecoveryState.Name),
+		RecoveryDescription: root.Cluster.RecoveryState.Description,
+		DatabaseLocked:      root.Cluster.DatabaseLockState.Locked,
+		LatencyProbe:        root.Cluster.LatencyProbe,
+		FaultTolerance:      root.Cluster.FaultTolerance,
+		RedundancyMode:      root.Cluster.Configuration.RedundancyMode,
+	}
 }
 
-func UpdateClusterHealth(f func(ClusterHealth)) func(process.Update) {
+func UpdateClusterHealth(h *history.History, r *recovery.Tracker, f func(ClusterHealth)) func(process.Update) {
 	return func(dsu process.Update) {
-		f(ClusterHealth{
-			Healthy:             dsu.Root.Cluster.Data.State.Health,
-			Health:              Titlify(dsu.Root.Cluster.Data.State.Name),
-			MinReplicas:         dsu.Root.Cluster.Data.State.MinReplicasRemaining,
-			RebalanceQueued:     dsu.Root.Cluster.Data.MovingData.InQueueBytes,
-			RebalanceInFlight:   dsu.Root.Cluster.Data.MovingData.InFlightBytes,
-			RecoveryState:       Titlify(dsu.Root.Cluster.RecoveryState.Name),
-			RecoveryDescription: dsu.Root.Cluster.RecoveryState.Description,
-			DatabaseLocked:      dsu.Root.Cluster.DatabaseLockState.Locked,
-		})
+		ch := ClusterHealthOf(dsu.Root)
+		ch.History = h.Cluster()
+		ch.Recoveries = SummariseRecoveries(r.Recoveries(), time.Now())
+		f(ch)
 	}
 }
 
@@ -74,14 +95,14 @@ var StatRecoveryDescription = components.ColumnImpl[ClusterHealth]{
 var StatRebalanceQueued = components.ColumnImpl[ClusterHealth]{
 	ColName: "Rebalance Queued",
 	DataFn: func(h ClusterHealth) string {
-		return Convert(float64(h.RebalanceQueued), 1, None)
+		return fmt.Sprintf("%s %s", Convert(float64(h.RebalanceQueued), 1, None), clusterSparkline(h.History, func(s history.ClusterSample) float64 { return s.MovingDataQueued }))
 	},
 }
 
 var StatRebalanceInflight = components.ColumnImpl[ClusterHealth]{
 	ColName: "Rebalance In-flight",
 	DataFn: func(h ClusterHealth) string {
-		return Convert(float64(h.RebalanceInFlight), 1, None)
+		return fmt.Sprintf("%s %s", Convert(float64(h.RebalanceInFlight), 1, None), clusterSparkline(h.History, func(s history.ClusterSample) float64 { return s.MovingDataInFlight }))
 	},
 }
 
@@ -99,9 +120,77 @@ var StatDatabaseLocked = components.ColumnImpl[ClusterHealth]{
 	},
 }
 
-var StatEmpty = components.ColumnImpl[ClusterHealth]{
-	ColName: "",
+func latencyColour(seconds float64, threshold time.Duration) tcell.Color {
+	latency := time.Duration(seconds * float64(time.Second))
+
+	if latency > threshold*latencyCriticalFactor {
+		return tcell.ColorRed
+	} else if latency > threshold {
+		return tcell.ColorYellow
+	} else {
+		return tcell.ColorWhite
+	}
+}
+
+var StatLatencyProbeGRV = components.ColumnImpl[ClusterHealth]{
+	ColName: "GRV Latency",
+	DataFn: func(h ClusterHealth) string {
+		return Milliseconds(h.LatencyProbe.TransactionStartSeconds)
+	},
+	ColorFn: func(h ClusterHealth) tcell.Color {
+		return latencyColour(h.LatencyProbe.TransactionStartSeconds, *grvLatencyThreshold)
+	},
+}
+
+var StatLatencyProbeRead = components.ColumnImpl[ClusterHealth]{
+	ColName: "Read Latency",
+	DataFn: func(h ClusterHealth) string {
+		return Milliseconds(h.LatencyProbe.ReadSeconds)
+	},
+	ColorFn: func(h ClusterHealth) tcell.Color {
+		return latencyColour(h.LatencyProbe.ReadSeconds, *readLatencyThreshold)
+	},
+}
+
+var StatLatencyProbeCommit = components.ColumnImpl[ClusterHealth]{
+	ColName: "Commit Latency",
+	DataFn: func(h ClusterHealth) string {
+		return Milliseconds(h.LatencyProbe.CommitSeconds)
+	},
+	ColorFn: func(h ClusterHealth) tcell.Color {
+		return latencyColour(h.LatencyProbe.CommitSeconds, *commitLatencyThreshold)
+	},
+}
+
+func (h ClusterHealth) FaultToleranceDegraded() (int, bool) {
+	expected, ok := ExpectedZoneFailures(h.RedundancyMode)
+	if !ok {
+		return 0, false
+	}
+
+	tolerance := h.FaultTolerance.MaxZoneFailuresWithoutLosingData
+	if h.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability < tolerance {
+		tolerance = h.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability
+	}
+
+	return expected, tolerance < expected
+}
+
+var StatFaultTolerance = components.ColumnImpl[ClusterHealth]{
+	ColName: "Zone Failures (Data / Avail)",
 	DataFn: func(h ClusterHealth) string {
-		return ""
+		text := fmt.Sprintf("%d / %d", h.FaultTolerance.MaxZoneFailuresWithoutLosingData, h.FaultTolerance.MaxZoneFailuresWithoutLosingAvailability)
+
+		if expected, degraded := h.FaultToleranceDegraded(); degraded {
+			text = fmt.Sprintf("%s (expected %d)", text, expected)
+		}
+
+		return text
+	},
+	ColorFn: func(h ClusterHealth) tcell.Color {
+		if _, degraded := h.FaultToleranceDegraded(); degraded {
+			return tcell.ColorRed
+		}
+		return tcell.ColorWhite
 	},
 }
@@ -2,10 +2,14 @@ package views
 
 import (
 	"fmt"
+	"github.com/pwood/fdbexplorer/data/fdb"
 	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
 	"github.com/pwood/fdbexplorer/output/ui/data/process"
 )
 
+const SparklineWidth = 10
+
 type ClusterStats struct {
 	TxStarted    float64
 	TxCommitted  float64
@@ -16,41 +20,53 @@ type ClusterStats struct {
 	Writes       float64
 	BytesRead    float64
 	BytesWritten float64
+
+	History []history.ClusterSample
 }
 
-func UpdateClusterStats(f func(ClusterStats)) func(process.Update) {
+func ClusterStatsOf(root fdb.Root) ClusterStats {
+	return ClusterStats{
+		TxStarted:    root.Cluster.Workload.Transactions.Started.Hz,
+		TxCommitted:  root.Cluster.Workload.Transactions.Committed.Hz,
+		TxConflicted: root.Cluster.Workload.Transactions.Conflicted.Hz,
+		TxRejected:   root.Cluster.Workload.Transactions.RejectedForQueuedTooLong.Hz,
+		Reads:        root.Cluster.Workload.Operations.Reads.Hz,
+		Writes:       root.Cluster.Workload.Operations.Writes.Hz,
+		BytesRead:    root.Cluster.Workload.Bytes.Read.Hz,
+		BytesWritten: root.Cluster.Workload.Bytes.Written.Hz,
+	}
+}
+
+func UpdateClusterStats(h *history.History, f func(ClusterStats)) func(process.Update) {
 	return func(dsu process.Update) {
-		f(ClusterStats{
-			TxStarted:    dsu.Root.Cluster.Workload.Transactions.Started.Hz,
-			TxCommitted:  dsu.Root.Cluster.Workload.Transactions.Committed.Hz,
-			TxConflicted: dsu.Root.Cluster.Workload.Transactions.Conflicted.Hz,
-			TxRejected:   dsu.Root.Cluster.Workload.Transactions.RejectedForQueuedTooLong.Hz,
-			Reads:        dsu.Root.Cluster.Workload.Operations.Reads.Hz,
-			Writes:       dsu.Root.Cluster.Workload.Operations.Writes.Hz,
-			BytesRead:    dsu.Root.Cluster.Workload.Bytes.Read.Hz,
-			BytesWritten: dsu.Root.Cluster.Workload.Bytes.Written.Hz,
-		})
+		cs := ClusterStatsOf(dsu.Root)
+		cs.History = h.Cluster()
+		f(cs)
 	}
 }
 
+func clusterSparkline(samples []history.ClusterSample, fn func(history.ClusterSample) float64) string {
+	return components.Sparkline(history.Series(samples, fn), SparklineWidth)
+}
+
 var StatTxStarted = components.ColumnImpl[ClusterStats]{
 	ColName: "Tx Started",
 	DataFn: func(cs ClusterStats) string {
-		return fmt.Sprintf("%0.1f/s", cs.TxStarted)
+		return fmt.Sprintf("%0.1f/s %s", cs.TxStarted, clusterSparkline(cs.History, func(s history.ClusterSample) float64 { return s.TxStarted }))
 	},
 }
 
 var StatTxCommitted = components.ColumnImpl[ClusterStats]{
 	ColName: "Tx Committed",
 	DataFn: func(cs ClusterStats) string {
-		return fmt.Sprintf("%0.1f/s", cs.TxCommitted)
+		return fmt.Sprintf("%0.1f/s %s", cs.TxCommitted, clusterSparkline(cs.History, func(s history.ClusterSample) float64 { return s.TxCommitted }))
 	},
 }
 
 var StatTxConflicted = components.ColumnImpl[ClusterStats]{
 	ColName: "Tx Conflicted",
 	DataFn: func(cs ClusterStats) string {
-		return fmt.Sprintf("%0.1f/s", cs.TxConflicted)
+		return fmt.Sprintf("%0.1f/s %s", cs.TxConflicted, clusterSparkline(cs.History, func(s history.ClusterSample) float64 { return s.TxConflicted }))
 	},
 }
 
@@ -64,14 +80,14 @@ var StatTxRejected = components.ColumnImpl[ClusterStats]{
 var StatReads = components.ColumnImpl[ClusterStats]{
 	ColName: "Reads",
 	DataFn: func(cs ClusterStats) string {
-		return fmt.Sprintf("%0.1f/s", cs.Reads)
+		return fmt.Sprintf("%0.1f/s %s", cs.Reads, clusterSparkline(cs.History, func(s history.ClusterSample) float64 { return s.Reads }))
 	},
 }
 
 var StatWrites = components.ColumnImpl[ClusterStats]{
 	ColName: "Writes",
 	DataFn: func(cs ClusterStats) string {
-		return fmt.Sprintf("%0.1f/s", cs.Writes)
+		return fmt.Sprintf("%0.1f/s %s", cs.Writes, clusterSparkline(cs.History, func(s history.ClusterSample) float64 { return s.Writes }))
 	},
 }
 
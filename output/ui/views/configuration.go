@@ -0,0 +1,302 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"sort"
+)
+
+func UpdateConfiguration(f func(fdb.Configuration)) func(process.Update) {
+	return func(dsu process.Update) {
+		f(dsu.Root.Cluster.Configuration)
+	}
+}
+
+var StatRedundancyMode = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Redundancy Mode",
+	DataFn: func(c fdb.Configuration) string {
+		return Titlify(c.RedundancyMode)
+	},
+}
+
+var StatStorageEngine = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Storage Engine",
+	DataFn: func(c fdb.Configuration) string {
+		return c.StorageEngine
+	},
+}
+
+var StatUsableRegions = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Usable Regions",
+	DataFn: func(c fdb.Configuration) string {
+		return fmt.Sprintf("%d", c.UsableRegions)
+	},
+}
+
+var StatCoordinators = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Coordinators",
+	DataFn: func(c fdb.Configuration) string {
+		return fmt.Sprintf("%d", c.CoordinatorsCount)
+	},
+}
+
+var StatStorageWiggle = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Perpetual Storage Wiggle",
+	DataFn: func(c fdb.Configuration) string {
+		return Boolify(c.PerpetualStorageWiggle > 0)
+	},
+}
+
+var StatStorageWiggleLocality = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Wiggle Locality",
+	DataFn: func(c fdb.Configuration) string {
+		if c.PerpetualStorageWiggleLocality == "" || c.PerpetualStorageWiggleLocality == "0" {
+			return "Any"
+		}
+		return c.PerpetualStorageWiggleLocality
+	},
+}
+
+var StatStorageMigration = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Storage Migration",
+	DataFn: func(c fdb.Configuration) string {
+		return Titlify(c.StorageMigrationType)
+	},
+}
+
+var StatExcludedServers = components.ColumnImpl[fdb.Configuration]{
+	ColName: "Excluded Servers",
+	DataFn: func(c fdb.Configuration) string {
+		return fmt.Sprintf("%d", len(c.ExcludedServers))
+	},
+}
+
+type RoleRecruitment struct {
+	Role       string
+	Configured int
+	Recruited  int
+}
+
+func (r RoleRecruitment) Mismatch() bool {
+	return r.Configured != r.Recruited
+}
+
+func configured(explicit, auto int) int {
+	if explicit > 0 {
+		return explicit
+	}
+	return auto
+}
+
+func expectedLogs(c fdb.Configuration) int {
+	logs := configured(c.Logs, c.AutoLogs)
+	expected := logs
+
+	if c.UsableRegions > 1 {
+		expected += configured(c.RemoteLogs, logs)
+	}
+
+	if len(c.Regions) > 0 {
+		primary := c.Regions[0]
+
+		for _, r := range c.Regions {
+			if len(r.Datacenters) > 0 && len(primary.Datacenters) > 0 && r.Datacenters[0].Priority > primary.Datacenters[0].Priority {
+				primary = r
+			}
+		}
+
+		for _, dc := range primary.Datacenters {
+			if dc.Satellite > 0 {
+				expected += primary.SatelliteLogs
+				break
+			}
+		}
+	}
+
+	return expected
+}
+
+func UpdateRoleRecruitment(f func([]RoleRecruitment)) func(process.Update) {
+	return func(dsu process.Update) {
+		c := dsu.Root.Cluster.Configuration
+
+		recruited := make(map[string]int)
+		for _, p := range dsu.Root.Cluster.Processes {
+			for _, r := range p.Roles {
+				recruited[r.Role]++
+			}
+		}
+
+		recruitments := []RoleRecruitment{
+			{Role: "log", Configured: expectedLogs(c)},
+		}
+
+		if c.CommitProxies == 0 && c.AutoCommitProxies == 0 && c.Proxies > 0 {
+			recruitments = append(recruitments, RoleRecruitment{Role: "proxy", Configured: c.Proxies})
+		} else {
+			recruitments = append(recruitments,
+				RoleRecruitment{Role: "commit_proxy", Configured: configured(c.CommitProxies, c.AutoCommitProxies)},
+				RoleRecruitment{Role: "grv_proxy", Configured: configured(c.GRVProxies, c.AutoGRVProxies)})
+		}
+
+		recruitments = append(recruitments, RoleRecruitment{Role: "resolver", Configured: configured(c.Resolvers, c.AutoResolvers)})
+
+		for i := range recruitments {
+			recruitments[i].Recruited = recruited[recruitments[i].Role]
+		}
+
+		f(recruitments)
+	}
+}
+
+func recruitmentColour(r RoleRecruitment) tcell.Color {
+	if r.Mismatch() {
+		return tcell.ColorYellow
+	}
+	return tcell.ColorWhite
+}
+
+var ColumnRecruitmentRole = components.ColumnImpl[RoleRecruitment]{
+	ColName: "Role",
+	DataFn: func(r RoleRecruitment) string {
+		return r.Role
+	},
+	ColorFn: recruitmentColour,
+}
+
+var ColumnRecruitmentConfigured = components.ColumnImpl[RoleRecruitment]{
+	ColName: "Configured",
+	DataFn: func(r RoleRecruitment) string {
+		return fmt.Sprintf("%d", r.Configured)
+	},
+	ColorFn: recruitmentColour,
+}
+
+var ColumnRecruitmentRecruited = components.ColumnImpl[RoleRecruitment]{
+	ColName: "Recruited",
+	DataFn: func(r RoleRecruitment) string {
+		return fmt.Sprintf("%d", r.Recruited)
+	},
+	ColorFn: recruitmentColour,
+}
+
+var ColumnRecruitmentStatus = components.ColumnImpl[RoleRecruitment]{
+	ColName: "Status",
+	DataFn: func(r RoleRecruitment) string {
+		if r.Mismatch() {
+			return "Mismatch"
+		}
+		return "OK"
+	},
+	ColorFn: recruitmentColour,
+}
+
+type ConfiguredDatacenter struct {
+	Region int
+	fdb.RegionDatacenter
+	SatelliteRedundancyMode string
+	SatelliteLogs           int
+}
+
+func UpdateConfiguredDatacenters(f func([]ConfiguredDatacenter)) func(process.Update) {
+	return func(dsu process.Update) {
+		var dcs []ConfiguredDatacenter
+
+		for i, region := range dsu.Root.Cluster.Configuration.Regions {
+			for _, dc := range region.Datacenters {
+				dcs = append(dcs, ConfiguredDatacenter{
+					Region:                  i + 1,
+					RegionDatacenter:        dc,
+					SatelliteRedundancyMode: region.SatelliteRedundancyMode,
+					SatelliteLogs:           region.SatelliteLogs,
+				})
+			}
+		}
+
+		f(dcs)
+	}
+}
+
+var ColumnDatacenterRegion = components.ColumnImpl[ConfiguredDatacenter]{
+	ColName: "Region",
+	DataFn: func(dc ConfiguredDatacenter) string {
+		return fmt.Sprintf("%d", dc.Region)
+	},
+}
+
+var ColumnDatacenterId = components.ColumnImpl[ConfiguredDatacenter]{
+	ColName: "Datacenter",
+	DataFn: func(dc ConfiguredDatacenter) string {
+		return dc.Id
+	},
+}
+
+var ColumnDatacenterPriority = components.ColumnImpl[ConfiguredDatacenter]{
+	ColName: "Priority",
+	DataFn: func(dc ConfiguredDatacenter) string {
+		return fmt.Sprintf("%d", dc.Priority)
+	},
+}
+
+var ColumnDatacenterSatellite = components.ColumnImpl[ConfiguredDatacenter]{
+	ColName: "Satellite?",
+	DataFn: func(dc ConfiguredDatacenter) string {
+		return Boolify(dc.Satellite > 0)
+	},
+}
+
+var ColumnDatacenterSatelliteRedundancy = components.ColumnImpl[ConfiguredDatacenter]{
+	ColName: "Satellite Redundancy",
+	DataFn: func(dc ConfiguredDatacenter) string {
+		return Titlify(dc.SatelliteRedundancyMode)
+	},
+}
+
+var ColumnDatacenterSatelliteLogs = components.ColumnImpl[ConfiguredDatacenter]{
+	ColName: "Satellite Logs",
+	DataFn: func(dc ConfiguredDatacenter) string {
+		return fmt.Sprintf("%d", dc.SatelliteLogs)
+	},
+}
+
+func UpdateExcludedServers(f func([]fdb.ExcludedServer)) func(process.Update) {
+	return func(dsu process.Update) {
+		servers := append([]fdb.ExcludedServer(nil), dsu.Root.Cluster.Configuration.ExcludedServers...)
+
+		sort.Slice(servers, func(i, j int) bool {
+			return servers[i].Address+servers[i].Locality < servers[j].Address+servers[j].Locality
+		})
+
+		f(servers)
+	}
+}
+
+var ColumnExcludedServer = components.ColumnImpl[fdb.ExcludedServer]{
+	ColName: "Excluded Server",
+	DataFn: func(es fdb.ExcludedServer) string {
+		if es.Address != "" {
+			return es.Address
+		}
+		return es.Locality
+	},
+}
+
+var ColumnExcludedServerType = components.ColumnImpl[fdb.ExcludedServer]{
+	ColName: "Type",
+	DataFn: func(es fdb.ExcludedServer) string {
+		if es.Address != "" {
+			return "Address"
+		}
+		return "Locality"
+	},
+}
+
+var StatEmptyConfiguration = components.ColumnImpl[fdb.Configuration]{
+	ColName: "",
+	DataFn: func(c fdb.Configuration) string {
+		return ""
+	},
+}
@@ -3,6 +3,7 @@ package views
 import (
 	"fmt"
 	"strings"
+	"time"
 )
 
 const Kibibyte float64 = 1024
@@ -22,6 +23,19 @@ func Boolify(b bool) string {
 	}
 }
 
+func Durationify(d time.Duration) string {
+	switch {
+	case d >= time.Hour && int(d.Minutes())%60 == 0:
+		return fmt.Sprintf("%dh", int(d.Hours()))
+	case d >= time.Hour:
+		return fmt.Sprintf("%dh%dm", int(d.Hours()), int(d.Minutes())%60)
+	case d >= time.Minute:
+		return fmt.Sprintf("%dm", int(d.Minutes()))
+	default:
+		return fmt.Sprintf("%ds", int(d.Seconds()))
+	}
+}
+
 const None string = ""
 
 func Convert(v float64, dp int, perUnit string) string {
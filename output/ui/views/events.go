@@ -0,0 +1,56 @@
+package views
+
+import (
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/events"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+func EventColour(e events.Event) tcell.Color {
+	switch e.Kind {
+	case events.KindProcessRemoved, events.KindProcessRestarted, events.KindAvailability:
+		return tcell.ColorRed
+	case events.KindRecoveryState, events.KindDataState, events.KindProcessHealth, events.KindRoleRecruited, events.KindRoleRemoved:
+		return tcell.ColorYellow
+	case events.KindExclusionComplete:
+		return tcell.ColorGreen
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+var ColumnEventTime = components.ColumnImpl[events.Event]{
+	ColName: "Time",
+	DataFn: func(e events.Event) string {
+		return e.Time.Format(messageTimeFormat)
+	},
+	ColorFn: EventColour,
+}
+
+var ColumnEventKind = components.ColumnImpl[events.Event]{
+	ColName: "Kind",
+	DataFn: func(e events.Event) string {
+		return e.Kind
+	},
+	ColorFn: EventColour,
+}
+
+var ColumnEventSubject = components.ColumnImpl[events.Event]{
+	ColName: "Subject",
+	DataFn: func(e events.Event) string {
+		if e.Subject == process.ClusterSubject {
+			return "Cluster"
+		}
+		return e.Subject
+	},
+	ColorFn: EventColour,
+}
+
+var ColumnEventDescription = components.ColumnImpl[events.Event]{
+	ColName: "Description",
+	DataFn: func(e events.Event) string {
+		return e.Description
+	},
+	ColorFn: EventColour,
+}
@@ -0,0 +1,180 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"sort"
+)
+
+type HeatmapMachine struct {
+	Name      string
+	Processes []process.Process
+}
+
+type HeatmapRow struct {
+	DataCenter string
+	Zone       string
+	Machines   []HeatmapMachine
+}
+
+func BuildHeatmap(processes []process.Process) []HeatmapRow {
+	zones := make(map[[2]string]map[string][]process.Process)
+
+	for _, p := range processes {
+		key := [2]string{p.FDBData.Locality[fdb.LocalityDataCenter], p.FDBData.Locality[fdb.LocalityZoneID]}
+
+		if _, ok := zones[key]; !ok {
+			zones[key] = make(map[string][]process.Process)
+		}
+
+		machine := p.FDBData.Locality[fdb.LocalityMachineID]
+		zones[key][machine] = append(zones[key][machine], p)
+	}
+
+	var rows []HeatmapRow
+
+	for key, machines := range zones {
+		row := HeatmapRow{DataCenter: key[0], Zone: key[1]}
+
+		for name, procs := range machines {
+			row.Machines = append(row.Machines, HeatmapMachine{Name: name, Processes: procs})
+		}
+
+		sort.Slice(row.Machines, func(i, j int) bool {
+			return row.Machines[i].Name < row.Machines[j].Name
+		})
+
+		rows = append(rows, row)
+	}
+
+	sort.Slice(rows, func(i, j int) bool {
+		if rows[i].DataCenter != rows[j].DataCenter {
+			return rows[i].DataCenter < rows[j].DataCenter
+		}
+		return rows[i].Zone < rows[j].Zone
+	})
+
+	return rows
+}
+
+type HeatmapMetric struct {
+	Name    string
+	ValueFn func(HeatmapMachine) (string, float64)
+}
+
+var HeatmapMetrics = []HeatmapMetric{
+	{
+		Name: "Health",
+		ValueFn: func(m HeatmapMachine) (string, float64) {
+			worst := process.HealthNormal
+			for _, p := range m.Processes {
+				if p.Metadata.Health < worst {
+					worst = p.Metadata.Health
+				}
+			}
+
+			switch worst {
+			case process.HealthCritical:
+				return "Crit", 1
+			case process.HealthWarning:
+				return "Warn", 0.5
+			default:
+				return "OK", 0
+			}
+		},
+	},
+	{
+		Name: "CPU",
+		ValueFn: worstProcessValue(func(p process.Process) float64 {
+			return p.FDBData.CPU.UsageCores
+		}, 1, percentLabel),
+	},
+	{
+		Name: "Disk Busy",
+		ValueFn: worstProcessValue(func(p process.Process) float64 {
+			return p.FDBData.Disk.Busy
+		}, 1, percentLabel),
+	},
+	{
+		Name: "Disk Full",
+		ValueFn: worstProcessValue(func(p process.Process) float64 {
+			if p.FDBData.Disk.TotalBytes == 0 {
+				return 0
+			}
+			return float64(p.FDBData.Disk.TotalBytes-p.FDBData.Disk.FreeBytes) / float64(p.FDBData.Disk.TotalBytes)
+		}, 1, percentLabel),
+	},
+	{
+		Name: "Storage Lag",
+		ValueFn: worstProcessValue(func(p process.Process) float64 {
+			for _, r := range p.FDBData.Roles {
+				if r.Role == "storage" {
+					return r.DataLag.Seconds
+				}
+			}
+			return 0
+		}, 5, func(v float64) string {
+			return fmt.Sprintf("%0.1fs", v)
+		}),
+	},
+	{
+		Name: "Log Queue",
+		ValueFn: worstProcessValue(func(p process.Process) float64 {
+			for _, r := range p.FDBData.Roles {
+				if r.Role == "log" {
+					return r.InputBytes.Counter - r.DurableBytes.Counter
+				}
+			}
+			return 0
+		}, 1.5*Gibibyte, func(v float64) string {
+			return Convert(v, 0, None)
+		}),
+	},
+}
+
+func percentLabel(v float64) string {
+	return fmt.Sprintf("%0.0f%%", v*100)
+}
+
+func worstProcessValue(fn func(process.Process) float64, limit float64, label func(float64) string) func(HeatmapMachine) (string, float64) {
+	return func(m HeatmapMachine) (string, float64) {
+		worst := 0.0
+
+		for _, p := range m.Processes {
+			if v := fn(p); v > worst {
+				worst = v
+			}
+		}
+
+		severity := worst / limit
+		if severity > 1 {
+			severity = 1
+		}
+
+		return label(worst), severity
+	}
+}
+
+func HeatmapColour(m HeatmapMachine, severity float64) tcell.Color {
+	excluded := true
+	for _, p := range m.Processes {
+		if !p.FDBData.Excluded {
+			excluded = false
+		}
+	}
+
+	switch {
+	case excluded:
+		return tcell.ColorBlue
+	case severity >= 0.9:
+		return tcell.ColorRed
+	case severity >= 0.75:
+		return tcell.ColorOrange
+	case severity >= 0.5:
+		return tcell.ColorOlive
+	default:
+		return tcell.ColorDarkGreen
+	}
+}
@@ -6,7 +6,7 @@ import (
 	"github.com/rivo/tview"
 )
 
-var helpKeyText = []string{"Sort", "Snapshot", "Interval", "-", "Refresh", "-", "Include", "Exclude"}
+var helpKeyText = []string{"Sort", "Snapshot", "Interval", "Events", "Refresh", "Cluster", "Include", "Exclude"}
 
 type HelpKeys struct {
 	tview.TableContentReadOnly
@@ -14,6 +14,7 @@ type HelpKeys struct {
 	Sorter   *process.SortControl
 	Interval *IntervalControl
 	HasEM    bool
+	Cluster  func() string
 }
 
 func (h *HelpKeys) GetCell(_, column int) *tview.TableCell {
@@ -24,6 +25,12 @@ func (h *HelpKeys) GetCell(_, column int) *tview.TableCell {
 		text = fmt.Sprintf("%s (%s)", helpKeyText[column], h.Sorter.SortName())
 	case 2:
 		text = fmt.Sprintf("%s (%s)", helpKeyText[column], h.Interval.Duration().String())
+	case 5:
+		if h.Cluster != nil {
+			text = fmt.Sprintf("%s (%s)", helpKeyText[column], h.Cluster())
+		} else {
+			text = "-"
+		}
 	case 6, 7:
 		if h.HasEM {
 			text = helpKeyText[column]
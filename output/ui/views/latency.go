@@ -0,0 +1,57 @@
+package views
+
+import (
+	"fmt"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"strings"
+)
+
+type percentile struct {
+	name  string
+	value func(fdb.LatencyStatistics) float64
+}
+
+var histogramPercentiles = []percentile{
+	{"p25", func(l fdb.LatencyStatistics) float64 { return l.P25 }},
+	{"p50", func(l fdb.LatencyStatistics) float64 { return l.Median }},
+	{"p90", func(l fdb.LatencyStatistics) float64 { return l.P90 }},
+	{"p95", func(l fdb.LatencyStatistics) float64 { return l.P95 }},
+	{"p99", func(l fdb.LatencyStatistics) float64 { return l.P99 }},
+	{"p99.9", func(l fdb.LatencyStatistics) float64 { return l.P999 }},
+	{"max", func(l fdb.LatencyStatistics) float64 { return l.Max }},
+}
+
+func LatencyHistogram(p process.Process, colour string, width int) []string {
+	var lines []string
+	width = max(width, 0)
+
+	for _, r := range p.FDBData.Roles {
+		name, stats, ok := r.LatencyStatistics()
+		if !ok || stats.Count == 0 {
+			continue
+		}
+
+		title := fmt.Sprintf("[%s]%s[-] %s latency (%0.0f samples)", colour, p.FDBData.Address, name, stats.Count)
+		if p.Metadata.LatencyOutlier(r.Role) {
+			title = fmt.Sprintf("%s [fuchsia]outlier[-]", title)
+		}
+
+		lines = append(lines, title)
+
+		for _, pc := range histogramPercentiles {
+			v := pc.value(stats)
+			bar := 0
+
+			if stats.Max > 0 {
+				bar = int(v / stats.Max * float64(width))
+			}
+
+			bar = min(max(bar, 0), width)
+
+			lines = append(lines, fmt.Sprintf("  %-5s [%s]%s[-]%s %s", pc.name, colour, strings.Repeat("█", bar), strings.Repeat(" ", width-bar), Milliseconds(v)))
+		}
+	}
+
+	return lines
+}
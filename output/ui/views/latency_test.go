@@ -0,0 +1,69 @@
+package views
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+func TestLatencyHistogram(t *testing.T) {
+	tests := []struct {
+		name  string
+		stats fdb.LatencyStatistics
+		width int
+		bars  []int
+	}{
+		{
+			name:  "scaled to max",
+			stats: fdb.LatencyStatistics{Count: 10, Max: 0.010, P25: 0.001, Median: 0.002, P90: 0.005, P95: 0.006, P99: 0.008, P999: 0.009},
+			width: 10,
+			bars:  []int{1, 2, 5, 6, 8, 9, 10},
+		},
+		{
+			name:  "percentile above max",
+			stats: fdb.LatencyStatistics{Count: 10, Max: 0.010, P25: 0.001, Median: 0.002, P90: 0.005, P95: 0.006, P99: 0.020, P999: 0.050},
+			width: 10,
+			bars:  []int{1, 2, 5, 6, 10, 10, 10},
+		},
+		{
+			name:  "negative percentile",
+			stats: fdb.LatencyStatistics{Count: 10, Max: 0.010, P25: -0.001, Median: 0.002, P90: 0.005, P95: 0.006, P99: 0.008, P999: 0.009},
+			width: 10,
+			bars:  []int{0, 2, 5, 6, 8, 9, 10},
+		},
+		{
+			name:  "zero max",
+			stats: fdb.LatencyStatistics{Count: 10, P99: 0.001},
+			width: 10,
+			bars:  []int{0, 0, 0, 0, 0, 0, 0},
+		},
+		{
+			name:  "negative width",
+			stats: fdb.LatencyStatistics{Count: 10, Max: 0.010, P99: 0.008},
+			width: -5,
+			bars:  []int{0, 0, 0, 0, 0, 0, 0},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			p := process.Process{
+				FDBData:  &fdb.Process{Address: "10.0.0.1:4500", Roles: []fdb.Role{{Role: "storage", ReadLatencyStatistics: test.stats}}},
+				Metadata: &process.Metadata{},
+			}
+
+			lines := LatencyHistogram(p, "white", test.width)
+			if len(lines) != len(test.bars)+1 {
+				t.Fatalf("LatencyHistogram() returned %d lines, want %d", len(lines), len(test.bars)+1)
+			}
+
+			for i, expected := range test.bars {
+				if actual := strings.Count(lines[i+1], "█"); actual != expected {
+					t.Errorf("%s bar = %d, want %d", histogramPercentiles[i].name, actual, expected)
+				}
+			}
+		})
+	}
+}
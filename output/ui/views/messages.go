@@ -0,0 +1,92 @@
+package views
+
+import (
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/messages"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+const messageTimeFormat = "2006-01-02 15:04:05"
+
+func MessageNameMatch(name string) func(messages.Entry) bool {
+	return func(e messages.Entry) bool {
+		return name == "" || e.Name == name
+	}
+}
+
+func MessageColour(e messages.Entry) tcell.Color {
+	if !e.Active {
+		return tcell.ColorGray
+	}
+
+	if e.Source == process.ClusterSubject {
+		return tcell.ColorYellow
+	}
+
+	return tcell.ColorWhite
+}
+
+var ColumnMessageSource = components.ColumnImpl[messages.Entry]{
+	ColName: "Source",
+	DataFn: func(e messages.Entry) string {
+		if e.Source == process.ClusterSubject {
+			return "Cluster"
+		}
+		return e.Source
+	},
+	ColorFn: MessageColour,
+}
+
+var ColumnMessageClass = components.ColumnImpl[messages.Entry]{
+	ColName: "Class",
+	DataFn: func(e messages.Entry) string {
+		return e.Class
+	},
+	ColorFn: MessageColour,
+}
+
+var ColumnMessageName = components.ColumnImpl[messages.Entry]{
+	ColName: "Name",
+	DataFn: func(e messages.Entry) string {
+		return e.Name
+	},
+	ColorFn: MessageColour,
+}
+
+var ColumnMessageDescription = components.ColumnImpl[messages.Entry]{
+	ColName: "Description",
+	DataFn: func(e messages.Entry) string {
+		return e.Description
+	},
+	ColorFn: MessageColour,
+}
+
+var ColumnMessageFirstSeen = components.ColumnImpl[messages.Entry]{
+	ColName: "First Seen",
+	DataFn: func(e messages.Entry) string {
+		return e.FirstSeen.Format(messageTimeFormat)
+	},
+	ColorFn: MessageColour,
+}
+
+var ColumnMessageLastSeen = components.ColumnImpl[messages.Entry]{
+	ColName: "Last Seen",
+	DataFn: func(e messages.Entry) string {
+		return e.LastSeen.Format(messageTimeFormat)
+	},
+	ColorFn: MessageColour,
+}
+
+var ColumnMessageActive = components.ColumnImpl[messages.Entry]{
+	ColName: "Active?",
+	DataFn: func(e messages.Entry) string {
+		if e.Active {
+			return "Yes"
+		}
+		return "Cleared " + time.Since(e.LastSeen).Truncate(time.Second).String() + " ago"
+	},
+	ColorFn: MessageColour,
+}
@@ -0,0 +1,55 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/data/history"
+)
+
+type ProcessChart struct {
+	Title    string
+	SampleFn func(history.ProcessSample) float64
+	FormatFn func(float64) string
+}
+
+var ProcessCharts = []ProcessChart{
+	{
+		Title:    "CPU Activity",
+		SampleFn: func(s history.ProcessSample) float64 { return s.CPU * 100 },
+		FormatFn: func(v float64) string { return fmt.Sprintf("%0.0f%%", v) },
+	},
+	{
+		Title:    "Disk Busy",
+		SampleFn: func(s history.ProcessSample) float64 { return s.DiskBusy * 100 },
+		FormatFn: func(v float64) string { return fmt.Sprintf("%0.0f%%", v) },
+	},
+	{
+		Title:    "Storage Data Lag",
+		SampleFn: func(s history.ProcessSample) float64 { return s.StorageDataLag },
+		FormatFn: func(v float64) string { return fmt.Sprintf("%0.1fs", v) },
+	},
+	{
+		Title:    "Storage Durability Lag",
+		SampleFn: func(s history.ProcessSample) float64 { return s.StorageDurabilityLag },
+		FormatFn: func(v float64) string { return fmt.Sprintf("%0.1fs", v) },
+	},
+	{
+		Title:    "Log Queue Length",
+		SampleFn: func(s history.ProcessSample) float64 { return s.LogQueueLength },
+		FormatFn: func(v float64) string { return Convert(v, 0, None) },
+	},
+	{
+		Title:    "KV Storage",
+		SampleFn: func(s history.ProcessSample) float64 { return s.KVUsedBytes },
+		FormatFn: func(v float64) string { return Convert(v, 0, None) },
+	},
+}
+
+var SeriesColours = []tcell.Color{
+	tcell.ColorGreen, tcell.ColorYellow, tcell.ColorAqua, tcell.ColorFuchsia,
+	tcell.ColorOrange, tcell.ColorBlue, tcell.ColorRed, tcell.ColorWhite,
+}
+
+func SeriesColour(i int) tcell.Color {
+	return SeriesColours[i%len(SeriesColours)]
+}
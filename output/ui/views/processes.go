@@ -18,6 +18,22 @@ func Selected(p process.Process) bool {
 	return p.Metadata.Selected
 }
 
+func AllSelected(processes []process.Process) bool {
+	for _, p := range processes {
+		if !p.Metadata.Selected {
+			return false
+		}
+	}
+
+	return len(processes) > 0
+}
+
+func AddressMatch(address string) func(process.Process) bool {
+	return func(p process.Process) bool {
+		return address != "" && p.FDBData.Address == address
+	}
+}
+
 func RoleMatch(s string) func(process.Process) bool {
 	return func(process process.Process) bool {
 		for _, r := range process.FDBData.Roles {
@@ -275,6 +291,25 @@ var ColumnStorageTotalQueries = components.ColumnImpl[process.Process]{
 	ColorFn: ProcessColour,
 }
 
+var ColumnStorageReadLatency = components.ColumnImpl[process.Process]{
+	ColName: "Read p50 / p99",
+	DataFn: func(pd process.Process) string {
+		idx := findRole(pd.FDBData.Roles, "storage")
+		stats := pd.FDBData.Roles[idx].ReadLatencyStatistics
+		return fmt.Sprintf("%s / %s", Milliseconds(stats.Median), Milliseconds(stats.P99))
+	},
+	ColorFn: LatencyColour("storage"),
+}
+
+func LatencyColour(role string) func(process.Process) tcell.Color {
+	return func(pd process.Process) tcell.Color {
+		if pd.Metadata.LatencyOutlier(role) {
+			return tcell.ColorFuchsia
+		}
+		return ProcessColour(pd)
+	}
+}
+
 func findRole(roles []fdb.Role, role string) int {
 	for i, straw := range roles {
 		if straw.Role == role {
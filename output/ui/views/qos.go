@@ -0,0 +1,242 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+)
+
+var limitReasonDescriptions = map[string]string{
+	"workload":                              "The database is not being saturated by the workload.",
+	"storage_server_write_queue_size":       "Storage server performance (storage queue).",
+	"storage_server_write_bandwidth_mvcc":   "Storage server MVCC memory.",
+	"storage_server_readable_behind":        "Storage server version falling behind.",
+	"log_server_mvcc_write_bandwidth":       "Log server MVCC memory.",
+	"log_server_write_queue":                "Storage server performance (log queue).",
+	"storage_server_min_free_space":         "Low space on storage server.",
+	"storage_server_min_free_space_ratio":   "Low space on storage server.",
+	"log_server_min_free_space":             "Low space on log server.",
+	"log_server_min_free_space_ratio":       "Low space on log server.",
+	"storage_server_durability_lag":         "Storage server durable version falling behind.",
+	"storage_server_list_fetch_failed":      "Unable to fetch storage server list.",
+	"blob_worker_lag":                       "Blob worker granule version falling behind.",
+	"blob_worker_missing":                   "No blob workers are reporting metrics.",
+	"storage_server_write_queue_size_batch": "Storage server performance (storage queue) for batch priority.",
+}
+
+func LimitDescription(l fdb.PerformanceLimitedBy) string {
+	if l.Description != "" {
+		return l.Description
+	}
+	return limitReasonDescriptions[l.Name]
+}
+
+type QoS struct {
+	fdb.QoS
+	LimitingAddress string
+}
+
+func findRoleAddress(processes map[string]fdb.Process, id string) string {
+	if id == "" {
+		return ""
+	}
+
+	for _, p := range processes {
+		for _, r := range p.Roles {
+			if r.Id == id {
+				return p.Address
+			}
+		}
+	}
+
+	return ""
+}
+
+func UpdateQoS(f func(QoS)) func(process.Update) {
+	return func(dsu process.Update) {
+		qos := dsu.Root.Cluster.QoS
+
+		f(QoS{
+			QoS:             qos,
+			LimitingAddress: findRoleAddress(dsu.Root.Cluster.Processes, qos.PerformanceLimitedBy.ReasonServerId),
+		})
+	}
+}
+
+var StatLimitedBy = components.ColumnImpl[QoS]{
+	ColName: "Limited By",
+	DataFn: func(q QoS) string {
+		if q.LimitingAddress != "" {
+			return fmt.Sprintf("%s (%s)", Titlify(q.PerformanceLimitedBy.Name), q.LimitingAddress)
+		}
+		return Titlify(q.PerformanceLimitedBy.Name)
+	},
+	ColorFn: func(q QoS) tcell.Color {
+		if q.PerformanceLimitedBy.Name == "workload" || q.PerformanceLimitedBy.Name == "" {
+			return tcell.ColorGreen
+		}
+		return tcell.ColorYellow
+	},
+}
+
+var StatLimitedByDescription = components.ColumnImpl[QoS]{
+	ColName: "Reason",
+	DataFn: func(q QoS) string {
+		return LimitDescription(q.PerformanceLimitedBy)
+	},
+}
+
+var StatBatchLimitedBy = components.ColumnImpl[QoS]{
+	ColName: "Batch Limited By",
+	DataFn: func(q QoS) string {
+		return Titlify(q.BatchPerformanceLimitedBy.Name)
+	},
+	ColorFn: func(q QoS) tcell.Color {
+		if q.BatchPerformanceLimitedBy.Name == "workload" || q.BatchPerformanceLimitedBy.Name == "" {
+			return tcell.ColorGreen
+		}
+		return tcell.ColorYellow
+	},
+}
+
+var StatBatchLimitedByDescription = components.ColumnImpl[QoS]{
+	ColName: "Batch Reason",
+	DataFn: func(q QoS) string {
+		return LimitDescription(q.BatchPerformanceLimitedBy)
+	},
+}
+
+var StatTPSLimit = components.ColumnImpl[QoS]{
+	ColName: "TPS Limit / Released",
+	DataFn: func(q QoS) string {
+		return fmt.Sprintf("%0.1f/s / %0.1f/s", q.TransactionsPerSecondLimit, q.ReleasedTransactionsPerSecond)
+	},
+}
+
+var StatBatchTPSLimit = components.ColumnImpl[QoS]{
+	ColName: "Batch TPS Limit / Released",
+	DataFn: func(q QoS) string {
+		return fmt.Sprintf("%0.1f/s / %0.1f/s", q.BatchTransactionsPerSecondLimit, q.BatchReleasedTransactionsPerSecond)
+	},
+}
+
+var StatAutoThrottledTags = components.ColumnImpl[QoS]{
+	ColName: "Auto Throttled Tags",
+	DataFn: func(q QoS) string {
+		t := q.ThrottledTags.Auto
+		return fmt.Sprintf("%d (%d Busy Read / %d Busy Write / %d Recommended)", t.Count, t.BusyRead, t.BusyWrite, t.RecommendedOnly)
+	},
+	ColorFn: func(q QoS) tcell.Color {
+		if q.ThrottledTags.Auto.Count > 0 {
+			return tcell.ColorYellow
+		}
+		return tcell.ColorWhite
+	},
+}
+
+var StatManualThrottledTags = components.ColumnImpl[QoS]{
+	ColName: "Manual Throttled Tags",
+	DataFn: func(q QoS) string {
+		return fmt.Sprintf("%d", q.ThrottledTags.Manual.Count)
+	},
+}
+
+type QoSOffender struct {
+	Metric  string
+	Value   string
+	Address string
+}
+
+func storageQueue(r fdb.Role) float64 {
+	return r.InputBytes.Counter - r.DurableBytes.Counter
+}
+
+func worstRole(processes map[string]fdb.Process, role string, fn func(fdb.Role) float64) (float64, string) {
+	worst := 0.0
+	address := ""
+
+	for _, p := range processes {
+		for _, r := range p.Roles {
+			if r.Role == role {
+				if v := fn(r); v > worst || address == "" {
+					worst = v
+					address = p.Address
+				}
+			}
+		}
+	}
+
+	return worst, address
+}
+
+func UpdateQoSOffenders(f func([]QoSOffender)) func(process.Update) {
+	return func(dsu process.Update) {
+		qos := dsu.Root.Cluster.QoS
+		processes := dsu.Root.Cluster.Processes
+
+		_, storageQueueAddress := worstRole(processes, "storage", storageQueue)
+		_, durabilityLagAddress := worstRole(processes, "storage", func(r fdb.Role) float64 { return r.DurabilityLag.Seconds })
+		_, dataLagAddress := worstRole(processes, "storage", func(r fdb.Role) float64 { return r.DataLag.Seconds })
+		_, logQueueAddress := worstRole(processes, "log", storageQueue)
+
+		f([]QoSOffender{
+			{
+				Metric:  fmt.Sprintf("Limiting Process (%s)", Titlify(qos.PerformanceLimitedBy.Name)),
+				Value:   qos.PerformanceLimitedBy.ReasonServerId,
+				Address: findRoleAddress(processes, qos.PerformanceLimitedBy.ReasonServerId),
+			},
+			{
+				Metric:  "Worst Storage Queue",
+				Value:   fmt.Sprintf("%s (Limiting %s)", Convert(qos.WorstQueueBytesStorageServer, 1, None), Convert(qos.LimitingQueueBytesStorageServer, 1, None)),
+				Address: storageQueueAddress,
+			},
+			{
+				Metric:  "Worst Durability Lag",
+				Value:   fmt.Sprintf("%0.1fs (Limiting %0.1fs)", qos.WorstDurabilityLagStorageServer.Seconds, qos.LimitingDurabilityLagStorageServer.Seconds),
+				Address: durabilityLagAddress,
+			},
+			{
+				Metric:  "Worst Data Lag",
+				Value:   fmt.Sprintf("%0.1fs (Limiting %0.1fs)", qos.WorstDataLagStorageServer.Seconds, qos.LimitingDataLagStorageServer.Seconds),
+				Address: dataLagAddress,
+			},
+			{
+				Metric:  "Worst Log Queue",
+				Value:   Convert(qos.WorstQueueBytesLogServer, 1, None),
+				Address: logQueueAddress,
+			},
+		})
+	}
+}
+
+var ColumnQoSOffenderMetric = components.ColumnImpl[QoSOffender]{
+	ColName: "Metric",
+	DataFn: func(o QoSOffender) string {
+		return o.Metric
+	},
+}
+
+var ColumnQoSOffenderValue = components.ColumnImpl[QoSOffender]{
+	ColName: "Value",
+	DataFn: func(o QoSOffender) string {
+		return o.Value
+	},
+}
+
+var ColumnQoSOffenderProcess = components.ColumnImpl[QoSOffender]{
+	ColName: "Process",
+	DataFn: func(o QoSOffender) string {
+		if o.Address == "" {
+			return "-"
+		}
+		return fmt.Sprintf("%s ↵", o.Address)
+	},
+	ColorFn: func(o QoSOffender) tcell.Color {
+		if o.Address == "" {
+			return tcell.ColorWhite
+		}
+		return tcell.ColorAqua
+	},
+}
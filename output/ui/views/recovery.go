@@ -0,0 +1,143 @@
+package views
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/recovery"
+)
+
+const (
+	RecoveryRecentWindow   = 10 * time.Minute
+	RecoveryFrequentWindow = time.Hour
+	RecoveryFrequentCount  = 3
+)
+
+type RecoverySummary struct {
+	InProgress  bool
+	Current     recovery.Recovery
+	LastEnded   time.Time
+	RecentCount int
+}
+
+func SummariseRecoveries(recoveries []recovery.Recovery, now time.Time) RecoverySummary {
+	var s RecoverySummary
+
+	for _, r := range recoveries {
+		if r.InProgress {
+			s.InProgress = true
+			s.Current = r
+			continue
+		}
+
+		if r.End.After(s.LastEnded) {
+			s.LastEnded = r.End
+		}
+
+		if now.Sub(r.End) <= RecoveryFrequentWindow {
+			s.RecentCount++
+		}
+	}
+
+	return s
+}
+
+func (s RecoverySummary) Recent(now time.Time) bool {
+	return !s.LastEnded.IsZero() && now.Sub(s.LastEnded) <= RecoveryRecentWindow
+}
+
+func (s RecoverySummary) Frequent() bool {
+	return s.RecentCount >= RecoveryFrequentCount
+}
+
+func ColumnRecoveryTime(name string, fn func(recovery.Recovery) time.Time) components.ColumnImpl[recovery.Recovery] {
+	return components.ColumnImpl[recovery.Recovery]{
+		ColName: name,
+		DataFn: func(r recovery.Recovery) string {
+			if t := fn(r); !t.IsZero() {
+				return t.Format(messageTimeFormat)
+			}
+			return "Unknown"
+		},
+		ColorFn: RecoveryColour,
+	}
+}
+
+func RecoveryColour(r recovery.Recovery) tcell.Color {
+	if r.InProgress {
+		return tcell.ColorRed
+	}
+	return tcell.ColorWhite
+}
+
+var ColumnRecoveryStarted = ColumnRecoveryTime("Started", func(r recovery.Recovery) time.Time { return r.Start })
+
+var ColumnRecoveryEnded = ColumnRecoveryTime("Ended", func(r recovery.Recovery) time.Time { return r.End })
+
+var ColumnRecoveryDuration = components.ColumnImpl[recovery.Recovery]{
+	ColName: "Duration",
+	DataFn: func(r recovery.Recovery) string {
+		if d, ok := r.Duration(time.Now()); ok {
+			return d.Truncate(time.Millisecond).String()
+		}
+		return "Unknown"
+	},
+	ColorFn: RecoveryColour,
+}
+
+var ColumnRecoveryGeneration = components.ColumnImpl[recovery.Recovery]{
+	ColName: "Generation",
+	DataFn: func(r recovery.Recovery) string {
+		return fmt.Sprintf("%d", r.Generation)
+	},
+	ColorFn: RecoveryColour,
+}
+
+var ColumnRecoveryMaster = components.ColumnImpl[recovery.Recovery]{
+	ColName: "Master",
+	DataFn: func(r recovery.Recovery) string {
+		return r.Master
+	},
+	ColorFn: RecoveryColour,
+}
+
+var ColumnRecoveryStages = components.ColumnImpl[recovery.Recovery]{
+	ColName: "Stages",
+	DataFn: func(r recovery.Recovery) string {
+		return strings.Join(r.Stages, " > ")
+	},
+	ColorFn: RecoveryColour,
+}
+
+var StatRecentRecoveries = components.ColumnImpl[ClusterHealth]{
+	ColName: "Recent Recoveries",
+	DataFn: func(h ClusterHealth) string {
+		now := time.Now()
+
+		if h.Recoveries.InProgress {
+			d, _ := h.Recoveries.Current.Duration(now)
+			return fmt.Sprintf("In progress for %s", d.Truncate(time.Second))
+		}
+
+		if h.Recoveries.LastEnded.IsZero() {
+			return "None seen"
+		}
+
+		return fmt.Sprintf("%d in %s, last %s ago", h.Recoveries.RecentCount, Durationify(RecoveryFrequentWindow), Durationify(now.Sub(h.Recoveries.LastEnded)))
+	},
+	ColorFn: func(h ClusterHealth) tcell.Color {
+		now := time.Now()
+
+		switch {
+		case h.Recoveries.InProgress, h.Recoveries.Recent(now):
+			return tcell.ColorRed
+		case h.Recoveries.Frequent():
+			return tcell.ColorYellow
+		default:
+			return tcell.ColorWhite
+		}
+	},
+}
@@ -0,0 +1,33 @@
+package views
+
+import (
+	"flag"
+	"time"
+)
+
+const latencyCriticalFactor = 5
+
+var grvLatencyThreshold *time.Duration
+var readLatencyThreshold *time.Duration
+var commitLatencyThreshold *time.Duration
+
+func init() {
+	grvLatencyThreshold = flag.Duration("latency-grv-threshold", 100*time.Millisecond, "Latency probe transaction start time above which the header is coloured as a warning.")
+	readLatencyThreshold = flag.Duration("latency-read-threshold", 50*time.Millisecond, "Latency probe read time above which the header is coloured as a warning.")
+	commitLatencyThreshold = flag.Duration("latency-commit-threshold", 200*time.Millisecond, "Latency probe commit time above which the header is coloured as a warning.")
+}
+
+var redundancyZoneFailures = map[string]int{
+	"single":                    0,
+	"double":                    1,
+	"triple":                    2,
+	"three_data_hall":           2,
+	"three_data_hall_fallback":  1,
+	"three_datacenter":          2,
+	"three_datacenter_fallback": 1,
+}
+
+func ExpectedZoneFailures(redundancyMode string) (int, bool) {
+	failures, ok := redundancyZoneFailures[redundancyMode]
+	return failures, ok
+}
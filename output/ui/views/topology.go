@@ -0,0 +1,204 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"sort"
+	"strings"
+)
+
+type TopologyLevel int
+
+const (
+	TopologyRoot TopologyLevel = iota
+	TopologyDataCenter
+	TopologyDataHall
+	TopologyZone
+	TopologyMachine
+	TopologyProcess
+)
+
+var topologyLocalities = map[TopologyLevel]string{
+	TopologyDataCenter: fdb.LocalityDataCenter,
+	TopologyDataHall:   fdb.LocalityDataHall,
+	TopologyZone:       fdb.LocalityZoneID,
+	TopologyMachine:    fdb.LocalityMachineID,
+}
+
+type TopologyNode struct {
+	Level     TopologyLevel
+	Key       string
+	Name      string
+	Children  []*TopologyNode
+	Processes []process.Process
+}
+
+func BuildTopology(processes []process.Process) *TopologyNode {
+	root := &TopologyNode{Level: TopologyRoot, Name: "Cluster"}
+	root.Processes = processes
+	root.build()
+
+	return root
+}
+
+func (n *TopologyNode) build() {
+	if n.Level == TopologyMachine {
+		for _, p := range n.Processes {
+			n.Children = append(n.Children, &TopologyNode{
+				Level:     TopologyProcess,
+				Key:       n.Key + "/" + p.FDBData.Address,
+				Name:      p.FDBData.Address,
+				Processes: []process.Process{p},
+			})
+		}
+
+		return
+	}
+
+	childLevel := n.Level + 1
+	locality := topologyLocalities[childLevel]
+	groups := make(map[string][]process.Process)
+
+	for _, p := range n.Processes {
+		name := p.FDBData.Locality[locality]
+		if name == "" {
+			name = "(none)"
+		}
+
+		groups[name] = append(groups[name], p)
+	}
+
+	var names []string
+	for name := range groups {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	for _, name := range names {
+		child := &TopologyNode{
+			Level:     childLevel,
+			Key:       n.Key + "/" + name,
+			Name:      name,
+			Processes: groups[name],
+		}
+		child.build()
+
+		n.Children = append(n.Children, child)
+	}
+}
+
+func (n *TopologyNode) Excluded() int {
+	excluded := 0
+
+	for _, p := range n.Processes {
+		if p.FDBData.Excluded {
+			excluded++
+		}
+	}
+
+	return excluded
+}
+
+func (n *TopologyNode) AllSelected() bool {
+	return AllSelected(n.Processes)
+}
+
+func (n *TopologyNode) ClassCounts() string {
+	counts := make(map[string]int)
+
+	for _, p := range n.Processes {
+		counts[p.FDBData.Class]++
+	}
+
+	var classes []string
+	for class := range counts {
+		classes = append(classes, class)
+	}
+
+	sort.Strings(classes)
+
+	var parts []string
+	for _, class := range classes {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[class], class))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+func (n *TopologyNode) Usage() (cpu float64, diskBusy float64, rssBytes float64) {
+	if len(n.Processes) == 0 {
+		return 0, 0, 0
+	}
+
+	for _, p := range n.Processes {
+		cpu += p.FDBData.CPU.UsageCores
+		diskBusy += p.FDBData.Disk.Busy
+		rssBytes += float64(p.FDBData.Memory.RSSBytes)
+	}
+
+	count := float64(len(n.Processes))
+
+	return cpu / count, diskBusy / count, rssBytes
+}
+
+func (n *TopologyNode) Colour() tcell.Color {
+	if n.Level == TopologyProcess {
+		return ProcessColour(n.Processes[0])
+	}
+
+	worst := process.HealthNormal
+	active := 0
+
+	for _, p := range n.Processes {
+		switch p.Metadata.Health {
+		case process.HealthExcluded, process.HealthExcludedOnly:
+			continue
+		}
+
+		active++
+
+		if p.Metadata.Health < worst {
+			worst = p.Metadata.Health
+		}
+	}
+
+	switch {
+	case active == 0 && len(n.Processes) > 0:
+		return tcell.ColorBlue
+	case worst == process.HealthCritical:
+		return tcell.ColorRed
+	case worst == process.HealthWarning:
+		return tcell.ColorYellow
+	case n.AllSelected():
+		return tcell.ColorGreen
+	default:
+		return tcell.ColorWhite
+	}
+}
+
+func (n *TopologyNode) Label() string {
+	selected := " "
+	if n.AllSelected() {
+		selected = "*"
+	}
+
+	cpu, diskBusy, rssBytes := n.Usage()
+
+	if n.Level == TopologyProcess {
+		p := n.Processes[0]
+		return fmt.Sprintf("%s %s  %s / %s  CPU %0.1f%%  Disk %0.1f%%  RAM %s  %s", selected, n.Name, p.FDBData.Class,
+			ColumnRoles.Data(p), cpu*100, diskBusy*100, Convert(rssBytes, 1, None), ColumnStatus.Data(p))
+	}
+
+	label := fmt.Sprintf("%s %s  (%d processes: %s)  CPU %0.1f%%  Disk %0.1f%%  RAM %s", selected, n.Name,
+		len(n.Processes), n.ClassCounts(), cpu*100, diskBusy*100, Convert(rssBytes, 1, None))
+
+	if excluded := n.Excluded(); excluded > 0 {
+		label = fmt.Sprintf("%s  Excluded %d/%d", label, excluded, len(n.Processes))
+	}
+
+	return label
+}
@@ -0,0 +1,207 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/fdb"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var SingletonRoles = []string{"cluster_controller", "master", "ratekeeper", "data_distributor", "consistency_scan", "blob_manager", "encrypt_key_proxy"}
+
+var TransactionRoles = []string{"commit_proxy", "grv_proxy", "proxy", "resolver", "router"}
+
+var StatefulRoles = []string{"log", "storage"}
+
+func roleIn(role string, roles []string) bool {
+	for _, r := range roles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
+func StatelessRoleMatch(p process.Process) bool {
+	for _, r := range p.FDBData.Roles {
+		if roleIn(r.Role, SingletonRoles) || roleIn(r.Role, TransactionRoles) {
+			return true
+		}
+	}
+	return false
+}
+
+type TransactionRole struct {
+	fdb.Role
+	Process  process.Process
+	Stateful []string
+}
+
+func (t TransactionRole) Singleton() bool {
+	return roleIn(t.Role.Role, SingletonRoles)
+}
+
+func BuildTransactionRoles(processes []process.Process) []TransactionRole {
+	var roles []TransactionRole
+
+	for _, p := range processes {
+		var stateful []string
+
+		for _, r := range p.FDBData.Roles {
+			if roleIn(r.Role, StatefulRoles) {
+				stateful = append(stateful, r.Role)
+			}
+		}
+
+		for _, r := range p.FDBData.Roles {
+			if roleIn(r.Role, SingletonRoles) || roleIn(r.Role, TransactionRoles) {
+				roles = append(roles, TransactionRole{Role: r, Process: p, Stateful: stateful})
+			}
+		}
+	}
+
+	order := append(append([]string{}, SingletonRoles...), TransactionRoles...)
+	rank := func(role string) int {
+		for i, r := range order {
+			if r == role {
+				return i
+			}
+		}
+		return len(order)
+	}
+
+	sort.SliceStable(roles, func(i, j int) bool {
+		return rank(roles[i].Role.Role) < rank(roles[j].Role.Role)
+	})
+
+	return roles
+}
+
+func TransactionRoleColour(t TransactionRole) tcell.Color {
+	if t.Singleton() && len(t.Stateful) > 0 {
+		return tcell.ColorYellow
+	}
+	return ProcessColour(t.Process)
+}
+
+func Milliseconds(seconds float64) string {
+	return fmt.Sprintf("%0.2fms", seconds*1000)
+}
+
+func latencyBands(bands map[string]float64) string {
+	type band struct {
+		name  string
+		limit float64
+	}
+
+	var ordered []band
+
+	for name := range bands {
+		limit, err := strconv.ParseFloat(name, 64)
+		if err != nil || math.IsInf(limit, 0) {
+			continue
+		}
+		ordered = append(ordered, band{name: name, limit: limit})
+	}
+
+	sort.Slice(ordered, func(i, j int) bool {
+		return ordered[i].limit < ordered[j].limit
+	})
+
+	var parts []string
+
+	for _, b := range ordered {
+		parts = append(parts, fmt.Sprintf("≤%s %0.0f", Milliseconds(b.limit), bands[b.name]))
+	}
+
+	if v, ok := bands["inf"]; ok {
+		parts = append(parts, fmt.Sprintf("all %0.0f", v))
+	}
+
+	return strings.Join(parts, ", ")
+}
+
+var ColumnTransactionRole = components.ColumnImpl[TransactionRole]{
+	ColName: "Role",
+	DataFn: func(t TransactionRole) string {
+		return t.Role.Role
+	},
+	ColorFn: TransactionRoleColour,
+}
+
+var ColumnTransactionAddress = components.ColumnImpl[TransactionRole]{
+	ColName: "IP Address:Port",
+	DataFn: func(t TransactionRole) string {
+		return t.Process.FDBData.Address
+	},
+	ColorFn: TransactionRoleColour,
+}
+
+var ColumnTransactionClass = components.ColumnImpl[TransactionRole]{
+	ColName: "Class",
+	DataFn: func(t TransactionRole) string {
+		return t.Process.FDBData.Class
+	},
+	ColorFn: TransactionRoleColour,
+}
+
+func TransactionProcessColumn(c components.ColumnImpl[process.Process]) components.ColumnImpl[TransactionRole] {
+	return components.ColumnImpl[TransactionRole]{
+		ColName: c.ColName,
+		DataFn: func(t TransactionRole) string {
+			return c.DataFn(t.Process)
+		},
+		ColorFn: TransactionRoleColour,
+	}
+}
+
+var ColumnTransactionShared = components.ColumnImpl[TransactionRole]{
+	ColName: "Shares With",
+	DataFn: func(t TransactionRole) string {
+		return strings.Join(t.Stateful, ", ")
+	},
+	ColorFn: TransactionRoleColour,
+}
+
+var ColumnTransactionLatency = components.ColumnImpl[TransactionRole]{
+	ColName: "Latency p50 / p99 / max",
+	DataFn: func(t TransactionRole) string {
+		name, stats, ok := t.Role.LatencyStatistics()
+		if !ok {
+			return ""
+		}
+
+		if stats.Count == 0 {
+			return fmt.Sprintf("%s: no samples", name)
+		}
+
+		return fmt.Sprintf("%s %s / %s / %s", name, Milliseconds(stats.Median), Milliseconds(stats.P99), Milliseconds(stats.Max))
+	},
+	ColorFn: func(t TransactionRole) tcell.Color {
+		if t.Process.Metadata.LatencyOutlier(t.Role.Role) {
+			return tcell.ColorFuchsia
+		}
+		return TransactionRoleColour(t)
+	},
+}
+
+var ColumnTransactionMetrics = components.ColumnImpl[TransactionRole]{
+	ColName: "Metrics",
+	DataFn: func(t TransactionRole) string {
+		switch t.Role.Role {
+		case "commit_proxy", "proxy":
+			return fmt.Sprintf("Batch Window p50 %s", Milliseconds(t.CommitBatchingWindowSize.Median))
+		case "grv_proxy":
+			return fmt.Sprintf("Bands %s", latencyBands(t.GRVLatencyBands))
+		default:
+			// status json reports no batch or conflict metrics for resolvers or routers.
+			return ""
+		}
+	},
+	ColorFn: TransactionRoleColour,
+}
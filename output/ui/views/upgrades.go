@@ -0,0 +1,129 @@
+package views
+
+import (
+	"fmt"
+	"github.com/gdamore/tcell/v2"
+	"github.com/pwood/fdbexplorer/data/upgrade"
+	"github.com/pwood/fdbexplorer/output/ui/components"
+	"github.com/pwood/fdbexplorer/output/ui/data/process"
+	"strings"
+)
+
+func UpdateUpgradeReport(summary func(upgrade.Report), servers func([]upgrade.ServerVersion), unsupported func([]upgrade.Client)) func(process.Update) {
+	return func(dsu process.Update) {
+		report := upgrade.NewReport(dsu.Root, upgrade.TargetVersion())
+
+		summary(report)
+		servers(report.ServerVersions)
+		unsupported(report.UnsupportedClients())
+	}
+}
+
+var StatUpgradeTarget = components.ColumnImpl[upgrade.Report]{
+	ColName: "Target Version",
+	DataFn: func(r upgrade.Report) string {
+		if r.TargetVersion == "" {
+			return "None (-target-version)"
+		}
+		return r.TargetVersion
+	},
+}
+
+var StatUpgradeReady = components.ColumnImpl[upgrade.Report]{
+	ColName: "Upgrade Ready",
+	DataFn: func(r upgrade.Report) string {
+		return Boolify(r.Ready())
+	},
+	ColorFn: func(r upgrade.Report) tcell.Color {
+		if r.Ready() {
+			return tcell.ColorGreen
+		}
+		return tcell.ColorRed
+	},
+}
+
+var StatUpgradeServerVersions = components.ColumnImpl[upgrade.Report]{
+	ColName: "Server Versions",
+	DataFn: func(r upgrade.Report) string {
+		return fmt.Sprintf("%d", len(r.ServerVersions))
+	},
+}
+
+var StatUpgradeMixedVersions = components.ColumnImpl[upgrade.Report]{
+	ColName: "Mixed Versions",
+	DataFn: func(r upgrade.Report) string {
+		return Boolify(r.MixedVersions())
+	},
+	ColorFn: func(r upgrade.Report) tcell.Color {
+		if r.MixedVersions() {
+			return tcell.ColorYellow
+		}
+		return tcell.ColorWhite
+	},
+}
+
+var StatUpgradeClients = components.ColumnImpl[upgrade.Report]{
+	ColName: "Connected Clients",
+	DataFn: func(r upgrade.Report) string {
+		return fmt.Sprintf("%d", len(r.Clients))
+	},
+}
+
+var StatUpgradeMultiVersionClients = components.ColumnImpl[upgrade.Report]{
+	ColName: "Multi-version Clients",
+	DataFn: func(r upgrade.Report) string {
+		return fmt.Sprintf("%d of %d", r.MultiVersionClients(), len(r.Clients))
+	},
+}
+
+var ColumnServerVersion = components.ColumnImpl[upgrade.ServerVersion]{
+	ColName: "Version",
+	DataFn: func(sv upgrade.ServerVersion) string {
+		return sv.Version
+	},
+}
+
+var ColumnServerVersionProcesses = components.ColumnImpl[upgrade.ServerVersion]{
+	ColName: "Processes",
+	DataFn: func(sv upgrade.ServerVersion) string {
+		return fmt.Sprintf("%d", len(sv.Addresses))
+	},
+}
+
+var ColumnServerVersionOnTarget = components.ColumnImpl[upgrade.ServerVersion]{
+	ColName: "On Target?",
+	DataFn: func(sv upgrade.ServerVersion) string {
+		return Boolify(sv.OnTarget)
+	},
+}
+
+var ColumnServerVersionAddresses = components.ColumnImpl[upgrade.ServerVersion]{
+	ColName: "Addresses",
+	DataFn: func(sv upgrade.ServerVersion) string {
+		return strings.Join(sv.Addresses, ", ")
+	},
+}
+
+var ColumnUpgradeClientAddress = components.ColumnImpl[upgrade.Client]{
+	ColName: "Address",
+	DataFn: func(c upgrade.Client) string {
+		return c.Address
+	},
+	ColorFn: func(upgrade.Client) tcell.Color {
+		return tcell.ColorRed
+	},
+}
+
+var ColumnUpgradeClientLogGroup = components.ColumnImpl[upgrade.Client]{
+	ColName: "Log Group",
+	DataFn: func(c upgrade.Client) string {
+		return c.LogGroup
+	},
+}
+
+var ColumnUpgradeClientVersions = components.ColumnImpl[upgrade.Client]{
+	ColName: "Supported Client Versions",
+	DataFn: func(c upgrade.Client) string {
+		return strings.Join(c.ClientVersions, ", ")
+	},
+}